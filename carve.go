@@ -2,57 +2,335 @@ package strings
 
 import (
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
+// Carved describes a region found by CarveAll and the other CarveAll variants
+type Carved struct {
+	// Middle is the text between the start and end markers
+	Middle string
+	// Start is the byte offset of the start marker
+	Start int
+	// End is the byte offset immediately following the end marker
+	End int
+}
+
+// CarveOption configures the CarveWith marker matching
+type CarveOption func(o *carveOptions)
+
+// CarveInclusive includes the `start` and `end` markers in the CarveWith
+// `middle` result
+func CarveInclusive() CarveOption {
+	return func(o *carveOptions) {
+		o.inclusive = true
+	}
+}
+
+// CarveLast uses the last `start` marker found instead of the first, similar
+// to [strings.LastIndex]. The `end` marker is still the first one following
+// the `start` marker
+func CarveLast() CarveOption {
+	return func(o *carveOptions) {
+		o.last = true
+	}
+}
+
+// CarveFoldASCII matches the `start` and `end` markers with ASCII case
+// insensitivity, all other runes must match exactly
+func CarveFoldASCII() CarveOption {
+	return func(o *carveOptions) {
+		o.fold = carveFoldASCII
+	}
+}
+
+// CarveFold matches the `start` and `end` markers with Unicode case folding,
+// similar to [strings.EqualFold]
+func CarveFold() CarveOption {
+	return func(o *carveOptions) {
+		o.fold = carveFoldUnicode
+	}
+}
+
+// CarveScanEnd uses the Scanner given to find the `end` marker, ignoring any
+// quoted or escaped instances. A nil Scanner uses the NewScanner defaults
+func CarveScanEnd(s *Scanner) CarveOption {
+	return func(o *carveOptions) {
+		if o.scanner = s; s == nil {
+			o.scanner = gDefaultScanner
+		}
+		o.scanStart = false
+	}
+}
+
+// CarveScanBoth is like CarveScanEnd except that the Scanner is used to find
+// both the `start` and `end` markers
+func CarveScanBoth(s *Scanner) CarveOption {
+	return func(o *carveOptions) {
+		CarveScanEnd(s)(o)
+		o.scanStart = true
+	}
+}
+
+type carveFold uint8
+
+const (
+	carveFoldNone carveFold = iota
+	carveFoldASCII
+	carveFoldUnicode
+)
+
+type carveOptions struct {
+	inclusive bool
+	last      bool
+	fold      carveFold
+	scanner   *Scanner
+	scanStart bool
+}
+
+// CarveWith is like Carve except that the marker matching is configured with
+// the CarveOption functions given. The `after` segment always begins after
+// the `end` marker and without any options, CarveWith is the same as Carve
+func CarveWith(src, start, end string, options ...CarveOption) (before, middle, after string, found bool) {
+	var o carveOptions
+	for _, option := range options {
+		option(&o)
+	}
+
+	var startScanner *Scanner
+	if o.scanStart {
+		startScanner = o.scanner
+	}
+
+	head, headSize := o.index(startScanner, src, start, o.last)
+	if head < 0 {
+		return src, "", "", false
+	}
+	from := head + headSize
+
+	tail, tailSize := o.index(o.scanner, src[from:], end, false)
+	if tail < 0 {
+		return src, "", "", false
+	}
+	tail += from
+
+	before, after = src[:head], src[tail+tailSize:]
+	if o.inclusive {
+		middle = src[head : tail+tailSize]
+	} else {
+		middle = src[from:tail]
+	}
+	return before, middle, after, true
+}
+
+// index returns the byte offset and matched byte length of the first (or
+// `last`) `sep` within `src`, using the Scanner given to ignore quoted and
+// escaped text when not nil
+func (o *carveOptions) index(s *Scanner, src, sep string, last bool) (idx, size int) {
+	if s == nil && o.fold == carveFoldNone {
+		// fast path
+		if last {
+			return strings.LastIndex(src, sep), len(sep)
+		}
+		return strings.Index(src, sep), len(sep)
+	}
+
+	if sep == "" {
+		// empty sep always matches
+		if last {
+			return len(src), 0
+		}
+		return 0, 0
+	}
+
+	idx = -1
+	var st scanState
+	for pos, r := range src {
+		if s != nil && !s.step(&st, pos, r) {
+			continue
+		} else if n, ok := o.hasPrefix(src[pos:], sep); ok {
+			if idx, size = pos, n; !last {
+				return
+			}
+		}
+	}
+	return
+}
+
+// hasPrefix is like [strings.HasPrefix] with case folding, returning the
+// number of bytes in `src` which matched `prefix`
+func (o *carveOptions) hasPrefix(src, prefix string) (size int, ok bool) {
+	switch o.fold {
+	case carveFoldASCII:
+		if ok = len(src) >= len(prefix); ok {
+			for idx := 0; idx < len(prefix); idx++ {
+				if ok = asciiLower(src[idx]) == asciiLower(prefix[idx]); !ok {
+					return 0, false
+				}
+			}
+			size = len(prefix)
+		}
+		return
+	case carveFoldUnicode:
+		for _, pr := range prefix {
+			if size >= len(src) {
+				return 0, false
+			}
+			sr, width := utf8.DecodeRuneInString(src[size:])
+			if sr != pr && !equalFoldRune(sr, pr) {
+				return 0, false
+			}
+			size += width
+		}
+		return size, true
+	}
+	if ok = strings.HasPrefix(src, prefix); ok {
+		size = len(prefix)
+	}
+	return
+}
+
+// asciiLower returns the lowercase version of ASCII letters and the byte
+// as-is otherwise
+func asciiLower(b byte) byte {
+	if 'A' <= b && b <= 'Z' {
+		return b + 'a' - 'A'
+	}
+	return b
+}
+
+// equalFoldRune reports whether the two runes are equal under simple Unicode
+// case folding
+func equalFoldRune(a, b rune) bool {
+	for r := unicode.SimpleFold(a); r != a; r = unicode.SimpleFold(r) {
+		if r == b {
+			return true
+		}
+	}
+	return false
+}
+
+// cutFunc is the signature shared by [strings.Cut] and Scan
+type cutFunc func(src, sep string) (before, after string, found bool)
+
 // Carve finds the `start` and `end` markers in `src` and carves out the
 // "before carve", "middle of start/end range" and "after carve" segments. If
 // the `start` and `end` range is not `found` then the `before` will contain
 // the entire `src` input
 //
-// Carve is based on two calls to [strings.Cut], one for the `start` and again
-// for the `end`
+// Carve is a shortcut for CarveWith without any options, which is equivalent
+// to two calls to [strings.Cut], one for the `start` and again for the `end`
 func Carve(src, start, end string) (before, middle, after string, found bool) {
-	var b0, a0, b1, a1 string
-	if b0, a0, found = strings.Cut(src, start); found {
-		if b1, a1, found = strings.Cut(a0, end); found {
-			before = b0
-			middle = b1
-			after = a1
-			return
-		}
-	}
-	before = src
-	return
+	return CarveWith(src, start, end)
+}
+
+// CarveAll is like Carve except that CarveAll returns all the `start` and `end`
+// ranges found in `src`
+func CarveAll(src, start, end string) (carved []Carved) {
+	return carveAll(src, start, end, strings.Cut, strings.Cut)
+}
+
+// CarveReplace replaces each of the regions CarveAll would find, including
+// the `start` and `end` markers, with the result of calling `fn` with the
+// region's `middle` text
+func CarveReplace(src, start, end string, fn func(middle string) (replaced string)) (modified string) {
+	return carveReplace(src, CarveAll(src, start, end), fn)
 }
 
 // ScanCarve is like Carve except that ScanCarve uses Scan instead of [strings.Cut]
 // for finding the `end` separator
 func ScanCarve(src, start, end string) (before, middle, after string, found bool) {
-	var b0, a0, b1, a1 string
-	if b0, a0, found = strings.Cut(src, start); found {
-		if b1, a1, found = Scan(a0, end); found {
-			before = b0
-			middle = b1
-			after = a1
-			return
-		}
-	}
-	before = src
-	return
+	return gDefaultScanner.ScanCarve(src, start, end)
 }
 
 // ScanBothCarve is like ScanCarve except that ScanBothCarve uses Scan to find
 // both the `start` and `end` separators
 func ScanBothCarve(src, start, end string) (before, middle, after string, found bool) {
-	var b0, a0, b1, a1 string
-	if b0, a0, found = Scan(src, start); found {
-		if b1, a1, found = Scan(a0, end); found {
-			before = b0
-			middle = b1
-			after = a1
-			return
+	return gDefaultScanner.ScanBothCarve(src, start, end)
+}
+
+// CarveBalanced is like Carve except that CarveBalanced counts the nesting
+// depth of the `start` and `end` markers, returning the outermost balanced
+// region as the `middle`. If the first `start` is never balanced, a *ScanError
+// is returned and `found` is false
+//
+// CarveBalanced is a wrapper around Scanner.CarveBalanced using a Scanner
+// without any quotes or escaping
+func CarveBalanced(src, start, end string) (before, middle, after string, found bool, err error) {
+	return gPlainScanner.CarveBalanced(src, start, end)
+}
+
+// ScanCarveBalanced is like CarveBalanced except that quoted and escaped
+// markers are ignored, using the same rules as Scan
+//
+// ScanCarveBalanced is a wrapper around Scanner.CarveBalanced using the
+// NewScanner defaults
+func ScanCarveBalanced(src, start, end string) (before, middle, after string, found bool, err error) {
+	return gDefaultScanner.CarveBalanced(src, start, end)
+}
+
+// ScanCarveAll is like CarveAll except that ScanCarveAll uses Scan instead of
+// [strings.Cut] for finding the `end` separators
+func ScanCarveAll(src, start, end string) (carved []Carved) {
+	return gDefaultScanner.ScanCarveAll(src, start, end)
+}
+
+// ScanBothCarveAll is like ScanCarveAll except that ScanBothCarveAll uses Scan
+// to find both the `start` and `end` separators
+func ScanBothCarveAll(src, start, end string) (carved []Carved) {
+	return gDefaultScanner.ScanBothCarveAll(src, start, end)
+}
+
+// ScanCarveReplace is like CarveReplace except that ScanCarveReplace uses
+// ScanCarveAll to find the regions to replace
+func ScanCarveReplace(src, start, end string, fn func(middle string) (replaced string)) (modified string) {
+	return gDefaultScanner.ScanCarveReplace(src, start, end, fn)
+}
+
+// ScanBothCarveReplace is like CarveReplace except that ScanBothCarveReplace
+// uses ScanBothCarveAll to find the regions to replace
+func ScanBothCarveReplace(src, start, end string, fn func(middle string) (replaced string)) (modified string) {
+	return gDefaultScanner.ScanBothCarveReplace(src, start, end, fn)
+}
+
+// carveAll repeatedly uses the `cutStart` and `cutEnd` functions to find all
+// the `start` and `end` ranges within `src`
+func carveAll(src, start, end string, cutStart, cutEnd cutFunc) (carved []Carved) {
+	if start == "" && end == "" {
+		// nothing to carve
+		return
+	}
+	var offset int
+	for remainder := src; ; {
+		b0, a0, found := cutStart(remainder, start)
+		if !found {
+			break
+		}
+		b1, a1, found := cutEnd(a0, end)
+		if !found {
+			break
 		}
+		c := Carved{Middle: b1, Start: offset + len(b0)}
+		c.End = c.Start + len(start) + len(b1) + len(end)
+		carved = append(carved, c)
+		offset, remainder = c.End, a1
 	}
-	before = src
 	return
 }
+
+// carveReplace replaces all the `carved` regions within `src` with the
+// results of `fn`
+func carveReplace(src string, carved []Carved, fn func(middle string) (replaced string)) (modified string) {
+	if len(carved) == 0 {
+		return src
+	}
+	var buf strings.Builder
+	var last int
+	for _, c := range carved {
+		buf.WriteString(src[last:c.Start])
+		buf.WriteString(fn(c.Middle))
+		last = c.End
+	}
+	buf.WriteString(src[last:])
+	return buf.String()
+}
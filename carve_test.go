@@ -15,7 +15,9 @@
 package strings
 
 import (
+	"errors"
 	"math/rand"
+	"strings"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -142,6 +144,254 @@ func TestCarve(t *testing.T) {
 
 	})
 
+	Convey("CarveWith", t, func() {
+		checks := []struct {
+			label           string
+			src, start, end string
+			options         []CarveOption
+			b, m, a         string
+			ok              bool
+		}{
+			{
+				"no options",
+				"a <div>one</div> <div>two</div>", "<div>", "</div>",
+				nil,
+				"a ", "one", " <div>two</div>",
+				true,
+			},
+			{
+				"inclusive",
+				"a <div>one</div> <div>two</div>", "<div>", "</div>",
+				[]CarveOption{CarveInclusive()},
+				"a ", "<div>one</div>", " <div>two</div>",
+				true,
+			},
+			{
+				"last",
+				"a <div>one</div> <div>two</div> b", "<div>", "</div>",
+				[]CarveOption{CarveLast()},
+				"a <div>one</div> ", "two", " b",
+				true,
+			},
+			{
+				"last and inclusive",
+				"a <div>one</div> <div>two</div> b", "<div>", "</div>",
+				[]CarveOption{CarveLast(), CarveInclusive()},
+				"a <div>one</div> ", "<div>two</div>", " b",
+				true,
+			},
+			{
+				"last without end",
+				"a <div>one</div> <div>two", "<div>", "</div>",
+				[]CarveOption{CarveLast()},
+				"a <div>one</div> <div>two", "", "",
+				false,
+			},
+			{
+				"case sensitive",
+				"a <DIV>one</Div> b", "<div>", "</div>",
+				nil,
+				"a <DIV>one</Div> b", "", "",
+				false,
+			},
+			{
+				"fold ascii",
+				"a <DIV>one</Div> b", "<div>", "</div>",
+				[]CarveOption{CarveFoldASCII()},
+				"a ", "one", " b",
+				true,
+			},
+			{
+				"fold ascii is not unicode",
+				"a <ÜL>one</ül> b", "<ül>", "</ül>",
+				[]CarveOption{CarveFoldASCII()},
+				"a <ÜL>one</ül> b", "", "",
+				false,
+			},
+			{
+				"fold unicode",
+				"a <ÜL>one</ül> b", "<ül>", "</ül>",
+				[]CarveOption{CarveFold(), CarveInclusive()},
+				"a ", "<ÜL>one</ül>", " b",
+				true,
+			},
+			{
+				"fold unicode different widths",
+				"a \u212A-one-k b", "k-", "-K",
+				[]CarveOption{CarveFold()},
+				"a ", "one", " b",
+				true,
+			},
+			{
+				"scan end",
+				`a <p>"</p>"</p> b`, "<p>", "</p>",
+				[]CarveOption{CarveScanEnd(nil)},
+				"a ", `"</p>"`, " b",
+				true,
+			},
+			{
+				"scan both",
+				`"<p>" <p>"</p>"</p> b`, "<p>", "</p>",
+				[]CarveOption{CarveScanBoth(nil)},
+				`"<p>" `, `"</p>"`, " b",
+				true,
+			},
+			{
+				"scan both, fold and last",
+				`<P>one</P> <P>"</p>"</p> "<p>"`, "<p>", "</p>",
+				[]CarveOption{CarveScanBoth(NewScanner()), CarveFoldASCII(), CarveLast()},
+				`<P>one</P> `, `"</p>"`, ` "<p>"`,
+				true,
+			},
+			{
+				"empty markers",
+				"one", "", "",
+				[]CarveOption{CarveFold()},
+				"", "", "one",
+				true,
+			},
+		}
+
+		for _, check := range checks {
+			Convey(check.label, func() {
+				b, m, a, ok := CarveWith(check.src, check.start, check.end, check.options...)
+				So(b, ShouldEqual, check.b)
+				So(m, ShouldEqual, check.m)
+				So(a, ShouldEqual, check.a)
+				So(ok, ShouldEqual, check.ok)
+			})
+		}
+	})
+
+	Convey("CarveAll", t, func() {
+		So(CarveAll("nothing here", "<!-- start -->", "<!-- end -->"), ShouldBeNil)
+		So(CarveAll("nothing here", "", ""), ShouldBeNil)
+
+		src := "a <!-- start -->one<!-- end --> b <!-- start -->two<!-- end --> c <!-- start -->three"
+		carved := CarveAll(src, "<!-- start -->", "<!-- end -->")
+		So(carved, ShouldHaveLength, 2)
+		So(carved[0].Middle, ShouldEqual, "one")
+		So(src[carved[0].Start:carved[0].End], ShouldEqual, "<!-- start -->one<!-- end -->")
+		So(carved[1].Middle, ShouldEqual, "two")
+		So(src[carved[1].Start:carved[1].End], ShouldEqual, "<!-- start -->two<!-- end -->")
+	})
+
+	Convey("CarveReplace", t, func() {
+		src := "a {{one}} b {{two}} c {{three"
+		So(CarveReplace(src, "{{", "}}", strings.ToUpper), ShouldEqual, "a ONE b TWO c {{three")
+		So(CarveReplace("nothing", "{{", "}}", strings.ToUpper), ShouldEqual, "nothing")
+	})
+
+	Convey("ScanCarveAll", t, func() {
+		src := `a {{ "}}" }} b "{{" {{ two }}`
+		carved := ScanCarveAll(src, "{{", "}}")
+		So(carved, ShouldHaveLength, 1)
+		So(carved[0].Middle, ShouldEqual, ` "}}" `)
+		So(src[carved[0].Start:carved[0].End], ShouldEqual, `{{ "}}" }}`)
+
+		carved = ScanBothCarveAll(src, "{{", "}}")
+		So(carved, ShouldHaveLength, 2)
+		So(carved[0].Middle, ShouldEqual, ` "}}" `)
+		So(carved[1].Middle, ShouldEqual, ` two `)
+		So(src[carved[1].Start:carved[1].End], ShouldEqual, `{{ two }}`)
+	})
+
+	Convey("ScanCarveReplace", t, func() {
+		src := `a {{ "}}" }} b "{{" {{ two }}`
+		fn := func(middle string) string {
+			return "[" + strings.TrimSpace(middle) + "]"
+		}
+		So(ScanCarveReplace(src, "{{", "}}", fn), ShouldEqual, `a ["}}"] b "{{" {{ two }}`)
+		So(ScanBothCarveReplace(src, "{{", "}}", fn), ShouldEqual, `a ["}}"] b "{{" [two]`)
+	})
+
+	Convey("CarveBalanced", t, func() {
+		checks := []struct {
+			src, start, end string
+			b, m, a         string
+			ok              bool
+			err             bool
+		}{
+			{
+				"{{ if {{x}} }} after", "{{", "}}",
+				"", " if {{x}} ", " after",
+				true, false,
+			},
+			{
+				"f(a, g(b, h(c)), d) + i(e)", "(", ")",
+				"f", "a, g(b, h(c)), d", " + i(e)",
+				true, false,
+			},
+			{
+				") before (one) after", "(", ")",
+				") before ", "one", " after",
+				true, false,
+			},
+			{
+				"|one|two|", "|", "|",
+				"", "one", "two|",
+				true, false,
+			},
+			{
+				"no markers", "(", ")",
+				"no markers", "", "",
+				false, false,
+			},
+			{
+				"empty markers", "", "",
+				"empty markers", "", "",
+				false, false,
+			},
+			{
+				"f(a, g(b)", "(", ")",
+				"f(a, g(b)", "", "",
+				false, true,
+			},
+			{
+				`f(a, ")") + g`, "(", ")",
+				`f`, `a, "`, `") + g`,
+				true, false,
+			},
+		}
+
+		for _, check := range checks {
+			b, m, a, ok, err := CarveBalanced(check.src, check.start, check.end)
+			So(b, ShouldEqual, check.b)
+			So(m, ShouldEqual, check.m)
+			So(a, ShouldEqual, check.a)
+			So(ok, ShouldEqual, check.ok)
+			So(err != nil, ShouldEqual, check.err)
+		}
+
+		_, _, _, _, err := CarveBalanced("one\n  {{ two {{ three }}", "{{", "}}")
+		var se *ScanError
+		So(errors.As(err, &se), ShouldBeTrue)
+		So(se.Kind, ShouldEqual, ScanUnbalancedMarker)
+		So(se.Marker, ShouldEqual, "{{")
+		So(se.Offset, ShouldEqual, 6)
+		So(se.Error(), ShouldEqual, `unbalanced "{{" starting at line 2, column 3`)
+	})
+
+	Convey("ScanCarveBalanced", t, func() {
+		b, m, a, ok, err := ScanCarveBalanced(`f(a, ")", g(\)), "(") + g`, "(", ")")
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+		So(b, ShouldEqual, `f`)
+		So(m, ShouldEqual, `a, ")", g(\)), "("`)
+		So(a, ShouldEqual, ` + g`)
+
+		b, m, a, ok, err = ScanCarveBalanced(`"(" {{ "}}" {{ x }} }} after`, "{{", "}}")
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+		So(b, ShouldEqual, `"(" `)
+		So(m, ShouldEqual, ` "}}" {{ x }} `)
+		So(a, ShouldEqual, ` after`)
+
+		_, _, _, ok, err = ScanCarveBalanced(`f(a, ")"`, "(", ")")
+		So(ok, ShouldBeFalse)
+		So(err, ShouldNotBeNil)
+	})
+
 }
 
 func BenchmarkScanCarve(b *testing.B) {
@@ -15,48 +15,210 @@
 package strings
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultPathSnakeSep is the directory separator used by PathToSnake and
+// SnakeToPath
+const DefaultPathSnakeSep = "__"
+
+// gPathSnakeEscapes are the PathToSnake escape codes of the runes which are
+// not lower case ASCII letters or digits, see PathToSnake
+var gPathSnakeEscapes = map[rune]byte{
+	'_': '0',
+	'-': '1',
+	'.': '2',
+	' ': '3',
+}
 
-	"github.com/iancoleman/strcase"
+const (
+	// pathSnakeRune is the escape code of any other rune, followed by its
+	// code point in six lower case hexadecimal digits
+	pathSnakeRune = '4'
+	// pathSnakeByte is the escape code of invalid UTF-8 bytes, followed by
+	// the byte value in two lower case hexadecimal digits
+	pathSnakeByte = '5'
 )
 
 // PathToSnake trims any leading and trailing slashes and converts the
-// string to a snake_cased__directory_separated format where the
-// directory separator is two underscores
+// string to a reversible encoding of lower case ASCII letters, digits and
+// underscores, where the directory separator is two underscores, for example
+// "/this/path" becomes "this__path" and "/my-dir/HTTPServer" becomes
+// "my_1dir___h_t_t_p_server"
+//
+// Note that previous versions of PathToSnake snake_cased each segment, which
+// could not be reversed. Keys and DOM ids made from paths with any upper case
+// letters, dashes, periods, underscores or any other runes which are not
+// lower case ASCII letters or digits are different with this encoding and
+// need to be regenerated
+//
+// The path is cleaned in the same way as [path.Clean], with any ".."
+// segments removing the segment before, and the segments are encoded so that
+// SnakeToPath can always recover the cleaned path: SnakeToPath(PathToSnake(p))
+// is the same as path.Clean("/"+p) without the leading and trailing slashes
+//
+// Lower case ASCII letters and digits are kept as-is and all other runes are
+// escaped with an underscore followed by a code: upper case ASCII letters are
+// "_" followed by the lower case letter, underscores are "_0", dashes are
+// "_1", periods are "_2", spaces are "_3", all other runes are "_4" followed
+// by the six digit hexadecimal code point and invalid UTF-8 bytes are "_5"
+// followed by the two digit hexadecimal byte value. For example, "my_file.go"
+// becomes "my_0file_2go" and "/Foo/Bar-Baz" becomes "_foo___bar_1_baz". The
+// encoded segments never contain two underscores in a row and never end with
+// an underscore, so they never collide with the directory separator
 func PathToSnake(path string) (snake string) {
-	path = strings.TrimPrefix(path, "/")
-	path = strings.TrimSuffix(path, "/")
-	path = strings.ReplaceAll(path, "/", "--")
-	snake = strcase.ToSnake(path)
+	return PathToSnakeSep(path, DefaultPathSnakeSep)
+}
+
+// SnakeToPath is the inverse of PathToSnake, returning the cleaned path
+// without any leading or trailing slashes
+func SnakeToPath(snake string) (path string) {
+	return SnakeToPathSep(snake, DefaultPathSnakeSep)
+}
+
+// PathToSnakeSep is like PathToSnake except that the directory separator is
+// encoded with the `sep` given. To remain reversible, the `sep` must not
+// contain any letters or numbers and any underscores within the `sep` must be
+// the only runes, with at least two of them, like "__" or "___". Any other
+// `sep` is replaced with the DefaultPathSnakeSep
+func PathToSnakeSep(path, sep string) (snake string) {
+	var cleaned []string
+	for _, segment := range strings.Split(path, "/") {
+		switch segment {
+		case "", ".":
+		case "..":
+			if last := len(cleaned) - 1; last > -1 {
+				cleaned = cleaned[:last]
+			}
+		default:
+			cleaned = append(cleaned, segment)
+		}
+	}
+	for idx, segment := range cleaned {
+		cleaned[idx] = encodePathSnake(segment)
+	}
+	snake = strings.Join(cleaned, pathSnakeSep(sep))
 	return
 }
 
-// ToTitleWords title-cases all words in the given text
-func ToTitleWords(text string) (capitalized string) {
-	// inspired by: https://stackoverflow.com/a/70284562
-	var within bool
-	characters := make([]rune, len(text))
-	for idx, character := range []rune(text) {
-		if isLetter := unicode.IsLetter(character); isLetter && !within {
-			characters[idx] = unicode.ToTitle(character)
-			within = true
-		} else if isLetter {
-			characters[idx] = character
-		} else if within = unicode.IsNumber(character); within {
-			characters[idx] = character
-		} else {
-			characters[idx] = character
-			within = false
+// SnakeToPathSep is the inverse of PathToSnakeSep. Any invalid escapes are
+// kept as-is and any empty segments are dropped
+func SnakeToPathSep(snake, sep string) (path string) {
+	var segments []string
+	for _, segment := range strings.Split(snake, pathSnakeSep(sep)) {
+		if segment != "" {
+			segments = append(segments, decodePathSnake(segment))
 		}
 	}
-	capitalized = string(characters)
+	path = strings.Join(segments, "/")
 	return
 }
 
-// ToSpaced is a wrapper around strcase.ToDelimited with a space delimiter
+// pathSnakeSep returns the `sep` given if it can be used as a reversible
+// directory separator, otherwise returns the DefaultPathSnakeSep
+func pathSnakeSep(sep string) string {
+	if underscores := strings.Count(sep, "_"); underscores > 0 {
+		if underscores < 2 || underscores != len(sep) {
+			return DefaultPathSnakeSep
+		}
+	} else if sep == "" || strings.IndexFunc(sep, func(r rune) bool {
+		return !isWordDelimiter(r)
+	}) > -1 {
+		return DefaultPathSnakeSep
+	}
+	return sep
+}
+
+// encodePathSnake escapes the path `segment` given, see PathToSnake
+func encodePathSnake(segment string) (encoded string) {
+	var buf strings.Builder
+	for idx, r := range segment {
+		switch code, ok := gPathSnakeEscapes[r]; {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			buf.WriteRune(r)
+		case r >= 'A' && r <= 'Z':
+			buf.WriteByte('_')
+			buf.WriteRune(unicode.ToLower(r))
+		case ok:
+			buf.WriteByte('_')
+			buf.WriteByte(code)
+		case r == utf8.RuneError && !strings.HasPrefix(segment[idx:], string(utf8.RuneError)):
+			fmt.Fprintf(&buf, "_%c%02x", pathSnakeByte, segment[idx])
+		default:
+			fmt.Fprintf(&buf, "_%c%06x", pathSnakeRune, r)
+		}
+	}
+	encoded = buf.String()
+	return
+}
+
+// decodePathSnake reverses encodePathSnake
+func decodePathSnake(encoded string) (segment string) {
+	var buf strings.Builder
+	for idx := 0; idx < len(encoded); idx++ {
+		if encoded[idx] != '_' || idx+1 >= len(encoded) {
+			buf.WriteByte(encoded[idx])
+			continue
+		}
+		code := encoded[idx+1]
+		switch {
+		case code >= 'a' && code <= 'z':
+			buf.WriteByte(code - 'a' + 'A')
+			idx++
+			continue
+		case code == pathSnakeRune && idx+8 <= len(encoded):
+			if value, err := strconv.ParseUint(encoded[idx+2:idx+8], 16, 32); err == nil {
+				buf.WriteRune(rune(value))
+				idx += 7
+				continue
+			}
+		case code == pathSnakeByte && idx+4 <= len(encoded):
+			if value, err := strconv.ParseUint(encoded[idx+2:idx+4], 16, 8); err == nil {
+				buf.WriteByte(byte(value))
+				idx += 3
+				continue
+			}
+		default:
+			if r, ok := pathSnakeUnescape(code); ok {
+				buf.WriteRune(r)
+				idx++
+				continue
+			}
+		}
+		// invalid escapes are kept as-is
+		buf.WriteByte(encoded[idx])
+	}
+	segment = buf.String()
+	return
+}
+
+// pathSnakeUnescape returns the rune of the gPathSnakeEscapes `code` given
+func pathSnakeUnescape(code byte) (r rune, ok bool) {
+	for r, c := range gPathSnakeEscapes {
+		if c == code {
+			return r, true
+		}
+	}
+	return 0, false
+}
+
+// ToTitleWords title-cases all words in the given text, words which are
+// registered acronyms are replaced with their registered form
+//
+// ToTitleWords is a wrapper around CaseConverter.ToTitleWords using the
+// acronyms registered with RegisterAcronyms
+func ToTitleWords(text string) (capitalized string) {
+	return gCaseConverter.ToTitleWords(text)
+}
+
+// ToSpaced lower-cases all the Words in the text given and joins them with
+// a space delimiter
 func ToSpaced(text string) (spaced string) {
-	spaced = strcase.ToDelimited(text, ' ')
+	spaced = joinWords(Words(text), " ", strings.ToLower)
 	return
 }
 
@@ -67,18 +229,17 @@ func ToSpacedTitle(text string) (spacedTitle string) {
 	return
 }
 
-// ToSpacedCamel is a wrapper around ToSpaced and strcase.ToCamel for each
-// word. The difference between ToSpacedTitle and ToSpacedCamel is in what
-// is considered a capital letter. ToSpacedTitle uses unicode.ToTitle to
-// figure that out while ToSpacedCamel uses strcase.ToCamel for that
+// ToSpacedCamel capitalizes all the Words in the text given and joins them
+// with a space delimiter. The difference between ToSpacedTitle and
+// ToSpacedCamel is in what is considered a capital letter. ToSpacedTitle uses
+// unicode.ToTitle to figure that out while ToSpacedCamel upper-cases the first
+// letter of each word and lower-cases the rest. Words which are registered
+// acronyms are replaced with their registered form
+//
+// ToSpacedCamel is a wrapper around CaseConverter.ToSpacedCamel using the
+// acronyms registered with RegisterAcronyms
 func ToSpacedCamel(text string) (spacedCamel string) {
-	spaced := ToSpaced(text)
-	fields := strings.Fields(spaced)
-	for idx := 0; idx < len(fields); idx++ {
-		fields[idx] = strcase.ToCamel(fields[idx])
-	}
-	spacedCamel = strings.Join(fields, " ")
-	return
+	return gCaseConverter.ToSpacedCamel(text)
 }
 
 // ToDeepKey converts go template variables like `.ThisThing.Variable` to a
@@ -86,17 +247,58 @@ func ToSpacedCamel(text string) (spacedCamel string) {
 func ToDeepKey(text string) (deepKey string) {
 	parts := strings.Split(strings.TrimPrefix(text, "."), ".")
 	for _, part := range parts {
-		deepKey += "." + strcase.ToKebab(part)
+		deepKey += "." + toKebab(part)
 	}
 	return
 }
 
 // ToDeepVar is the opposite of ToDeepKey, translating `.this-thing.variable`
-// to `.ThisThing.Variable` format
+// to `.ThisThing.Variable` format. Words which are registered acronyms are
+// replaced with their registered form, for example `.api-url` is translated
+// to `.APIURL`
+//
+// ToDeepVar is a wrapper around CaseConverter.ToDeepVar using the acronyms
+// registered with RegisterAcronyms
 func ToDeepVar(text string) (deepVar string) {
-	parts := strings.Split(strings.TrimPrefix(text, "."), ".")
-	for _, part := range parts {
-		deepVar += "." + strcase.ToCamel(part)
+	return gCaseConverter.ToDeepVar(text)
+}
+
+// toKebab lower-cases all the Words in the text given and joins them with
+// a dash delimiter
+func toKebab(text string) (kebab string) {
+	return joinWords(Words(text), "-", strings.ToLower)
+}
+
+// toSnake lower-cases all the Words in the text given and joins them with
+// an underscore delimiter
+func toSnake(text string) (snake string) {
+	return joinWords(Words(text), "_", strings.ToLower)
+}
+
+// toPascal capitalizes all the Words in the text given and joins them
+// without any delimiter
+func toPascal(text string) (pascal string) {
+	return joinWords(Words(text), "", gCaseConverter.Capitalize)
+}
+
+// capitalize upper-cases the first rune of the word given and lower-cases the
+// rest
+func capitalize(word string) (capitalized string) {
+	if r, size := utf8.DecodeRuneInString(word); size > 0 {
+		capitalized = string(unicode.ToUpper(r)) + strings.ToLower(word[size:])
 	}
 	return
 }
+
+// joinWords applies the `fn` to each of the `words` and joins the results
+// with the `sep` given
+func joinWords(words []string, sep string, fn func(word string) string) (joined string) {
+	var buf strings.Builder
+	for idx, word := range words {
+		if idx > 0 {
+			buf.WriteString(sep)
+		}
+		buf.WriteString(fn(word))
+	}
+	return buf.String()
+}
@@ -15,6 +15,9 @@
 package strings
 
 import (
+	"math/rand"
+	"path"
+	"strings"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -23,9 +26,66 @@ import (
 func TestCases(t *testing.T) {
 	Convey("PathToSnake", t, func() {
 		So(PathToSnake(""), ShouldEqual, "")
-		So(PathToSnake("One"), ShouldEqual, "one")
-		So(PathToSnake("/One/"), ShouldEqual, "one")
-		So(PathToSnake("/One/Two"), ShouldEqual, "one__two")
+		So(PathToSnake("this/path"), ShouldEqual, "this__path")
+		So(PathToSnake("One"), ShouldEqual, "_one")
+		So(PathToSnake("/One/"), ShouldEqual, "_one")
+		So(PathToSnake("/One/Two"), ShouldEqual, "_one___two")
+		So(PathToSnake("/my-dir/HTTPServer/v2Api"), ShouldEqual, "my_1dir___h_t_t_p_server__v2_api")
+		So(PathToSnake("src/my_file.go"), ShouldEqual, "src__my_0file_2go")
+		So(PathToSnake("Foo/Bar-Baz"), ShouldEqual, "_foo___bar_1_baz")
+		So(PathToSnake("/one/./two/../three//---/"), ShouldEqual, "one__three___1_1_1")
+		So(PathToSnake("../one/my_dir"), ShouldEqual, "one__my_0dir")
+		So(PathToSnake("one/two/../../.."), ShouldEqual, "")
+		So(PathToSnake("a b/..."), ShouldEqual, "a_3b___2_2_2")
+		So(PathToSnake("日本/é"), ShouldEqual, "_40065e5_400672c___40000e9")
+		So(PathToSnake("\xff/\ufffd"), ShouldEqual, "_5ff___400fffd")
+	})
+
+	Convey("SnakeToPath", t, func() {
+		So(SnakeToPath(""), ShouldEqual, "")
+		So(SnakeToPath("one"), ShouldEqual, "one")
+		So(SnakeToPath("one__two"), ShouldEqual, "one/two")
+		So(SnakeToPath("my_1dir___h_t_t_p_server__v2_api"), ShouldEqual, "my-dir/HTTPServer/v2Api")
+		So(SnakeToPath("src__my_0file_2go"), ShouldEqual, "src/my_file.go")
+		So(SnakeToPath("_foo___bar_1_baz"), ShouldEqual, "Foo/Bar-Baz")
+		So(SnakeToPath("__one____two__"), ShouldEqual, "one/two")
+		So(SnakeToPath("_40065e5_5ff"), ShouldEqual, "日\xff")
+		// invalid escapes are kept as-is
+		So(SnakeToPath("one_"), ShouldEqual, "one_")
+		So(SnakeToPath("one_9_4zz_5z"), ShouldEqual, "one_9_4zz_5z")
+	})
+
+	Convey("PathToSnakeSep, SnakeToPathSep", t, func() {
+		So(PathToSnakeSep("/my-dir/HTTPServer", "--"), ShouldEqual, "my_1dir--_h_t_t_p_server")
+		So(SnakeToPathSep("my_1dir--_h_t_t_p_server", "--"), ShouldEqual, "my-dir/HTTPServer")
+		So(PathToSnakeSep("/one/two", "."), ShouldEqual, "one.two")
+		So(PathToSnakeSep("/one/Two", "___"), ShouldEqual, "one____two")
+		So(SnakeToPathSep("one____two", "___"), ShouldEqual, "one/Two")
+		So(PathToSnakeSep("/one/two", "_"), ShouldEqual, "one__two")
+		So(PathToSnakeSep("/one/two", "_-"), ShouldEqual, "one__two")
+		So(PathToSnakeSep("/one/two", "x"), ShouldEqual, "one__two")
+		So(PathToSnakeSep("/one/two", ""), ShouldEqual, "one__two")
+		So(SnakeToPathSep("one__two", "x"), ShouldEqual, "one/two")
+	})
+
+	Convey("PathToSnake, SnakeToPath properties", t, func() {
+		alphabet := []string{
+			"a", "B", "c", "D", "é", "Ü", "1", "2", "-", "_", "__", " ", ".",
+			"/", "..", "./", "../", "ID", "HTTP", "Server", "v2", "日本", "\xff",
+			"\ufffd", "_4", "_5",
+		}
+		r := rand.New(rand.NewSource(1))
+		for _, sep := range []string{DefaultPathSnakeSep, "--", ".", "/", "___"} {
+			for idx := 0; idx < 500; idx++ {
+				var p string
+				for count := r.Intn(16); count > 0; count-- {
+					p += alphabet[r.Intn(len(alphabet))]
+				}
+				snake := PathToSnakeSep(p, sep)
+				So(SnakeToPathSep(snake, sep), ShouldEqual, cleanedPath(p))
+				So(PathToSnakeSep(SnakeToPathSep(snake, sep), sep), ShouldEqual, snake)
+			}
+		}
 	})
 
 	Convey("ToTitleWords", t, func() {
@@ -35,11 +95,16 @@ func TestCases(t *testing.T) {
 		So(ToTitleWords("one1two"), ShouldEqual, "One1two")
 		So(ToTitleWords("one1 two"), ShouldEqual, "One1 Two")
 		So(ToTitleWords("one 1two"), ShouldEqual, "One 1two")
+		So(ToTitleWords("ünï\xffcödé"), ShouldEqual, "Ünï\xffCödé")
+		So(ToTitleWords("the user id"), ShouldEqual, "The User ID")
+		So(ToTitleWords("don't stop"), ShouldEqual, "Don't Stop")
 	})
 
 	Convey("ToSpaced", t, func() {
 		So(ToSpaced("one-two"), ShouldEqual, "one two")
 		So(ToSpaced("OneTwo"), ShouldEqual, "one two")
+		So(ToSpaced("HTTPServerID"), ShouldEqual, "http server id")
+		So(ToSpaced("v2Api"), ShouldEqual, "v2 api")
 	})
 
 	Convey("ToSpacedTitle", t, func() {
@@ -50,6 +115,8 @@ func TestCases(t *testing.T) {
 	Convey("ToSpacedCamel", t, func() {
 		So(ToSpacedCamel("one-twoTwo"), ShouldEqual, "One Two Two")
 		So(ToSpacedCamel("One_TwoTwo"), ShouldEqual, "One Two Two")
+		So(ToSpacedCamel("ünï_cödé"), ShouldEqual, "Ünï Cödé")
+		So(ToSpacedCamel("user_id"), ShouldEqual, "User ID")
 	})
 
 	Convey("ToDeepKey", t, func() {
@@ -60,5 +127,27 @@ func TestCases(t *testing.T) {
 	Convey("ToDeepVar", t, func() {
 		So(ToDeepVar(".OneThing.another_thing"), ShouldEqual, ".OneThing.AnotherThing")
 		So(ToDeepVar(".one-thing.TwoThings"), ShouldEqual, ".OneThing.TwoThings")
+		So(ToDeepVar(".api-url"), ShouldEqual, ".APIURL")
+	})
+}
+
+// cleanedPath is the expected SnakeToPath(PathToSnake(p)) result
+func cleanedPath(p string) (cleaned string) {
+	return strings.Trim(path.Clean("/"+p), "/")
+}
+
+func FuzzPathToSnake(f *testing.F) {
+	f.Add("/my-dir/HTTPServer/v2Api")
+	f.Add("one/./two/../three//---/")
+	f.Add("../日本/my_dir")
+	f.Add("src/my_file.go")
+	f.Add("Foo/Bar-Baz\xff")
+	f.Fuzz(func(t *testing.T, p string) {
+		snake := PathToSnake(p)
+		if cleaned := SnakeToPath(snake); cleaned != cleanedPath(p) {
+			t.Fatalf("SnakeToPath(PathToSnake(%q)) = %q, want %q", p, cleaned, cleanedPath(p))
+		} else if again := PathToSnake(cleaned); again != snake {
+			t.Fatalf("PathToSnake(%q) = %q, want %q", cleaned, again, snake)
+		}
 	})
 }
@@ -0,0 +1,391 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CaseStyle identifies a text casing convention
+type CaseStyle uint8
+
+const (
+	// UnknownCase is used for text without any upper or lower case letters
+	UnknownCase CaseStyle = iota
+	// SnakeCase is lower case words separated by underscores: one_two
+	SnakeCase
+	// KebabCase is lower case words separated by dashes: one-two
+	KebabCase
+	// CamelCase is capitalized words without separators, the first word is
+	// lower case: oneTwo
+	CamelCase
+	// PascalCase is capitalized words without separators: OneTwo
+	PascalCase
+	// ScreamingSnakeCase is upper case words separated by underscores: ONE_TWO
+	ScreamingSnakeCase
+	// TitleCase is capitalized words separated by spaces, minor words like
+	// "of" may be lower case: One of Two
+	TitleCase
+	// SentenceCase is lower case words separated by spaces, the first word is
+	// capitalized: One two
+	SentenceCase
+	// DotCase is lower case words separated by periods: one.two
+	DotCase
+	// PathCase is lower case words separated by slashes: one/two
+	PathCase
+	// MixedCase is used for text with letters which does not follow any of the
+	// other styles
+	MixedCase
+)
+
+// String returns the name of the CaseStyle, like "snake" or "screaming-snake"
+func (s CaseStyle) String() string {
+	switch s {
+	case SnakeCase:
+		return "snake"
+	case KebabCase:
+		return "kebab"
+	case CamelCase:
+		return "camel"
+	case PascalCase:
+		return "pascal"
+	case ScreamingSnakeCase:
+		return "screaming-snake"
+	case TitleCase:
+		return "title"
+	case SentenceCase:
+		return "sentence"
+	case DotCase:
+		return "dot"
+	case PathCase:
+		return "path"
+	case MixedCase:
+		return "mixed"
+	}
+	return "unknown"
+}
+
+// DetectCase returns the CaseStyle of the text given
+//
+// Text containing spaces is either TitleCase, when all the words are
+// capitalized, upper case or minor words (see ToTitleStyle), or SentenceCase,
+// when the first word of each sentence is capitalized and the rest are lower
+// case or upper case acronyms (see ToSentence). Punctuation within spaced text
+// is otherwise ignored
+//
+// All other text must use only one kind of separator, without any empty
+// words, and path text may also have leading and trailing slashes. Digits are
+// allowed anywhere within the words
+//
+// A single lower case word is valid in most of the styles and is reported as
+// SnakeCase, a single upper case word is reported as ScreamingSnakeCase and a
+// single capitalized word is reported as PascalCase
+func DetectCase(s string) (style CaseStyle) {
+	if strings.IndexFunc(s, isCasedLetter) < 0 {
+		return UnknownCase
+	} else if strings.ContainsRune(s, ' ') {
+		return detectSpacedCase(s)
+	}
+
+	var sep rune
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r) {
+			continue
+		} else if sep != 0 && r != sep {
+			return MixedCase
+		}
+		sep = r
+	}
+
+	switch sep {
+	case 0:
+		switch wordCaseOf(s) {
+		case lowerWord:
+			return SnakeCase
+		case upperWord:
+			return ScreamingSnakeCase
+		case camelWord:
+			return CamelCase
+		case capitalWord, pascalWord:
+			return PascalCase
+		}
+		return MixedCase
+	case '/':
+		s = strings.Trim(s, "/")
+	case '_', '-', '.':
+	default:
+		return MixedCase
+	}
+
+	lower, upper := true, true
+	for _, word := range strings.Split(s, string(sep)) {
+		switch wordCaseOf(word) {
+		case noCaseWord:
+			if word == "" {
+				return MixedCase
+			}
+		case lowerWord:
+			upper = false
+		case upperWord:
+			lower = false
+		default:
+			return MixedCase
+		}
+	}
+
+	switch {
+	case lower && sep == '_':
+		return SnakeCase
+	case lower && sep == '-':
+		return KebabCase
+	case lower && sep == '.':
+		return DotCase
+	case lower && sep == '/':
+		return PathCase
+	case upper && sep == '_':
+		return ScreamingSnakeCase
+	}
+	return MixedCase
+}
+
+// detectSpacedCase returns the TitleCase, SentenceCase or MixedCase style of
+// the spaced text given
+func detectSpacedCase(s string) (style CaseStyle) {
+	title, sentence := true, true
+	spans := wordSpans(s)
+	for idx, span := range spans {
+		word := s[span[0]:span[1]]
+		switch wordCaseOf(word) {
+		case capitalWord:
+			sentence = sentence && (idx == 0 || isSentenceEnd(s[spans[idx-1][0]:spans[idx-1][1]], s[spans[idx-1][1]:span[0]]))
+		case upperWord, noCaseWord:
+		case camelWord, pascalWord:
+			// brand names like iPhone
+			if idx == 0 {
+				return MixedCase
+			}
+		case lowerWord:
+			sentence = sentence && idx > 0
+			title = title && idx > 0 && isMinorWord(word)
+		}
+	}
+	if title {
+		return TitleCase
+	} else if sentence {
+		return SentenceCase
+	}
+	return MixedCase
+}
+
+// isMinorWord returns true if the `word` is a minor word in any TitleStyle
+func isMinorWord(word string) bool {
+	for _, minor := range gTitleMinorWords {
+		if _, ok := minor[word]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// wordCase is the DetectCase classification of a single word
+type wordCase uint8
+
+const (
+	noCaseWord  wordCase = iota // no letters with case
+	lowerWord                   // all lower case
+	upperWord                   // all upper case
+	capitalWord                 // first letter upper case, rest lower case
+	camelWord                   // first letter lower case, some upper case
+	pascalWord                  // first letter upper case, mixed case
+)
+
+// wordCaseOf returns the wordCase of the `word` given, which must not have
+// any separators
+func wordCaseOf(word string) (wc wordCase) {
+	var seen, firstUpper, lowerAfter, upperAfter bool
+	for _, r := range word {
+		if !isCasedLetter(r) {
+			continue
+		} else if !seen {
+			seen, firstUpper = true, !unicode.IsLower(r)
+		} else if unicode.IsLower(r) {
+			lowerAfter = true
+		} else {
+			upperAfter = true
+		}
+	}
+	switch {
+	case !seen:
+		return noCaseWord
+	case firstUpper && !lowerAfter:
+		return upperWord
+	case firstUpper && !upperAfter:
+		return capitalWord
+	case firstUpper:
+		return pascalWord
+	case !upperAfter:
+		return lowerWord
+	}
+	return camelWord
+}
+
+// isCasedLetter returns true if the rune given is an upper, lower or title
+// case letter
+func isCasedLetter(r rune) bool {
+	return unicode.IsUpper(r) || unicode.IsLower(r) || unicode.IsTitle(r)
+}
+
+// ParseCaseStyle returns the CaseStyle with the name given, names are the
+// CaseStyle.String values and are matched in any case, with either dashes or
+// underscores, for example "screaming-snake" and "SCREAMING_SNAKE" are both
+// ScreamingSnakeCase
+func ParseCaseStyle(name string) (style CaseStyle, ok bool) {
+	key := toKebab(name)
+	for style = SnakeCase; style <= MixedCase; style++ {
+		if style.String() == key {
+			return style, true
+		}
+	}
+	return UnknownCase, false
+}
+
+// ToCase converts the text given to the CaseStyle given, the text is split
+// into Words and rejoined according to the style. UnknownCase and MixedCase
+// return the text as-is
+//
+// ToCase is a wrapper around CaseConverter.ToCase using the acronyms
+// registered with RegisterAcronyms
+func ToCase(style CaseStyle, text string) (converted string) {
+	return gCaseConverter.ToCase(style, text)
+}
+
+// ToCases converts all the given strings to the CaseStyle given, see ToCase
+func ToCases(style CaseStyle, inputs ...string) (out []string) {
+	for _, i := range inputs {
+		out = append(out, gCaseConverter.ToCase(style, i))
+	}
+	return
+}
+
+// ConvertCase is like ToCase except that the text is split into words using
+// the rules of the `from` CaseStyle instead of Words. Text in the separated
+// styles (snake, kebab, dot, path and screaming-snake) is split only on the
+// separator, keeping words like "v2api" or "ipv6" intact, and text in the
+// spaced styles (title and sentence) is split only on the word boundaries,
+// with apostrophes removed, so that "Don't Stop" converts to "dont_stop"
+// instead of "don_t_stop". All other `from` styles use Words
+//
+// ConvertCase is a wrapper around CaseConverter.ConvertCase using the
+// acronyms registered with RegisterAcronyms
+func ConvertCase(from, to CaseStyle, text string) (converted string) {
+	return gCaseConverter.ConvertCase(from, to, text)
+}
+
+// ToCase is the acronym-aware version of the package-level ToCase function
+func (c *CaseConverter) ToCase(style CaseStyle, text string) (converted string) {
+	if style == UnknownCase || style >= MixedCase {
+		return text
+	}
+	return c.joinCase(style, c.Words(text))
+}
+
+// ConvertCase is the acronym-aware version of the package-level ConvertCase
+// function
+func (c *CaseConverter) ConvertCase(from, to CaseStyle, text string) (converted string) {
+	if to == UnknownCase || to >= MixedCase {
+		return text
+	}
+
+	var words []string
+	switch from {
+	case SnakeCase, ScreamingSnakeCase:
+		words = splitWords(text, "_")
+	case KebabCase:
+		words = splitWords(text, "-")
+	case DotCase:
+		words = splitWords(text, ".")
+	case PathCase:
+		words = splitWords(text, "/")
+	case TitleCase, SentenceCase:
+		for _, span := range wordSpans(text) {
+			words = append(words, strings.Map(func(r rune) rune {
+				if isApostrophe(r) {
+					return -1
+				}
+				return r
+			}, text[span[0]:span[1]]))
+		}
+	default:
+		words = c.Words(text)
+	}
+
+	return c.joinCase(to, words)
+}
+
+// joinCase joins the `words` given according to the CaseStyle, which must
+// not be UnknownCase or MixedCase
+func (c *CaseConverter) joinCase(style CaseStyle, words []string) (joined string) {
+	switch style {
+	case SnakeCase:
+		return joinWords(words, "_", strings.ToLower)
+	case KebabCase:
+		return joinWords(words, "-", strings.ToLower)
+	case DotCase:
+		return joinWords(words, ".", strings.ToLower)
+	case PathCase:
+		return joinWords(words, "/", strings.ToLower)
+	case ScreamingSnakeCase:
+		return joinWords(words, "_", strings.ToUpper)
+	case PascalCase:
+		return joinWords(words, "", c.Capitalize)
+	case TitleCase:
+		return joinWords(words, " ", func(word string) string {
+			return c.titleWord(strings.ToLower(word))
+		})
+	}
+
+	// camel and sentence case treat the first word differently
+	var buf strings.Builder
+	for idx, word := range words {
+		switch {
+		case style == CamelCase && idx == 0:
+			buf.WriteString(strings.ToLower(word))
+		case style == CamelCase:
+			buf.WriteString(c.Capitalize(word))
+		case idx == 0:
+			buf.WriteString(c.Capitalize(word))
+		default:
+			buf.WriteString(" ")
+			if acronym, ok := c.Acronym(word); ok {
+				buf.WriteString(acronym)
+			} else {
+				buf.WriteString(strings.ToLower(word))
+			}
+		}
+	}
+	return buf.String()
+}
+
+// splitWords splits the text on the separator given, dropping any empty
+// words
+func splitWords(text, sep string) (words []string) {
+	for _, word := range strings.Split(text, sep) {
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	return
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCaseStyle(t *testing.T) {
+	Convey("DetectCase", t, func() {
+		checks := []struct {
+			input string
+			style CaseStyle
+		}{
+			{"", UnknownCase},
+			{"123 - 456", UnknownCase},
+			{"日本", UnknownCase},
+			{"one", SnakeCase},
+			{"one_two", SnakeCase},
+			{"v2_api_3", SnakeCase},
+			{"one-two", KebabCase},
+			{"oneTwo", CamelCase},
+			{"userID", CamelCase},
+			{"One", PascalCase},
+			{"OneTwo", PascalCase},
+			{"HTTPServer", PascalCase},
+			{"ONE", ScreamingSnakeCase},
+			{"ONE_TWO_3", ScreamingSnakeCase},
+			{"One Two", TitleCase},
+			{"Gone with the Wind", TitleCase},
+			{"The API of NASA", TitleCase},
+			{"One two", SentenceCase},
+			{"First name", SentenceCase},
+			{"Hello, world. Bye now!", SentenceCase},
+			{"Buy the new iPhone", SentenceCase},
+			{"one.two", DotCase},
+			{"one/two", PathCase},
+			{"/one/two/", PathCase},
+			{"one two", MixedCase},
+			{"Gone With the wind", MixedCase},
+			{"iPhone sales", MixedCase},
+			{"One_Two", MixedCase},
+			{"ONE_two", MixedCase},
+			{"one__two", MixedCase},
+			{"_one", MixedCase},
+			{"ONE-TWO", MixedCase},
+			{"one_two-three", MixedCase},
+			{"one+two", MixedCase},
+			{"dir/file.txt", MixedCase},
+		}
+		for _, check := range checks {
+			So(DetectCase(check.input), ShouldEqual, check.style)
+		}
+
+		So(UnknownCase.String(), ShouldEqual, "unknown")
+		So(ScreamingSnakeCase.String(), ShouldEqual, "screaming-snake")
+		So(MixedCase.String(), ShouldEqual, "mixed")
+		So(CaseStyle(255).String(), ShouldEqual, "unknown")
+	})
+
+	Convey("ParseCaseStyle", t, func() {
+		for style := SnakeCase; style <= MixedCase; style++ {
+			parsed, ok := ParseCaseStyle(style.String())
+			So(ok, ShouldBeTrue)
+			So(parsed, ShouldEqual, style)
+		}
+		style, ok := ParseCaseStyle("SCREAMING_SNAKE")
+		So(ok, ShouldBeTrue)
+		So(style, ShouldEqual, ScreamingSnakeCase)
+		style, ok = ParseCaseStyle("unknown")
+		So(ok, ShouldBeFalse)
+		So(style, ShouldEqual, UnknownCase)
+		_, ok = ParseCaseStyle("nope")
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("ToCase", t, func() {
+		checks := []struct {
+			style CaseStyle
+			input string
+			out   string
+		}{
+			{SnakeCase, "", ""},
+			{SnakeCase, "userID", "user_id"},
+			{KebabCase, "HTTPServer", "http-server"},
+			{CamelCase, "http_server_id", "httpServerID"},
+			{CamelCase, "UserName", "userName"},
+			{PascalCase, "user-id", "UserID"},
+			{ScreamingSnakeCase, "v2Api", "V2_API"},
+			{TitleCase, "the_api_url", "The API URL"},
+			{SentenceCase, "THE_API_URL", "The API URL"},
+			{SentenceCase, "firstName", "First name"},
+			{DotCase, "One Two", "one.two"},
+			{PathCase, "one_two", "one/two"},
+			{MixedCase, "one_Two", "one_Two"},
+			{UnknownCase, "one_Two", "one_Two"},
+		}
+		for _, check := range checks {
+			So(ToCase(check.style, check.input), ShouldEqual, check.out)
+		}
+
+		So(ToCases(SnakeCase), ShouldBeNil)
+		So(ToCases(KebabCase, "OneTwo", "userID"), ShouldEqual, []string{"one-two", "user-id"})
+
+		// ToCase is stable for all the styles with separators
+		for _, style := range []CaseStyle{SnakeCase, KebabCase, CamelCase, PascalCase, ScreamingSnakeCase, DotCase, PathCase} {
+			for _, input := range []string{"userID", "HTTPServer", "one two three", "v2 app"} {
+				converted := ToCase(style, input)
+				So(DetectCase(converted), ShouldEqual, style)
+				So(ToCase(style, converted), ShouldEqual, converted)
+			}
+		}
+	})
+
+	Convey("ConvertCase", t, func() {
+		So(ConvertCase(SnakeCase, CamelCase, "user_id"), ShouldEqual, "userID")
+		So(ConvertCase(CamelCase, SnakeCase, "userID"), ShouldEqual, "user_id")
+		So(ConvertCase(SnakeCase, KebabCase, "ipv6_addr"), ShouldEqual, "ipv6-addr")
+		So(ConvertCase(SnakeCase, PascalCase, "v2api_url"), ShouldEqual, "V2apiURL")
+		So(ToCase(PascalCase, "v2api_url"), ShouldEqual, "V2apiURL")
+		So(ConvertCase(KebabCase, SnakeCase, "one_two-three"), ShouldEqual, "one_two_three")
+		So(ConvertCase(DotCase, PathCase, "a.b.c"), ShouldEqual, "a/b/c")
+		So(ConvertCase(PathCase, DotCase, "/a/b/"), ShouldEqual, "a.b")
+		So(ConvertCase(ScreamingSnakeCase, TitleCase, "HTTP_SERVER"), ShouldEqual, "HTTP Server")
+		So(ConvertCase(TitleCase, SnakeCase, "Don't Stop"), ShouldEqual, "dont_stop")
+		So(ToCase(SnakeCase, "Don't Stop"), ShouldEqual, "don_t_stop")
+		So(ConvertCase(SentenceCase, KebabCase, "Hello, world."), ShouldEqual, "hello-world")
+		So(ConvertCase(MixedCase, SnakeCase, "oneTwo three"), ShouldEqual, "one_two_three")
+		So(ConvertCase(SnakeCase, MixedCase, "one_two"), ShouldEqual, "one_two")
+
+		c := NewCaseConverter()
+		So(c.ConvertCase(SnakeCase, CamelCase, "user_id"), ShouldEqual, "userId")
+		So(c.ToCase(PascalCase, "user_id"), ShouldEqual, "UserId")
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// update-psl regenerates the embedded public suffix list snapshot from a
+// local copy of the list, without any network access. Download the latest
+// list from https://publicsuffix.org/list/public_suffix_list.dat and then,
+// from the top of the repository, run:
+//
+//	go run ./cmd/update-psl -input /path/to/public_suffix_list.dat
+//
+// The input is parsed and validated before the snapshot is written, all
+// comments except for the license header, VERSION, COMMIT and the section
+// markers are removed and Unicode rules are converted to their ASCII form
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	clstrings "github.com/go-corelibs/strings"
+)
+
+func main() {
+	input := flag.String("input", "", "path to a local public_suffix_list.dat file")
+	output := flag.String("output", clstrings.PublicSuffixListFile, "path to the snapshot file to write")
+	flag.Parse()
+
+	if err := run(*input, *output); err != nil {
+		fmt.Fprintf(os.Stderr, "update-psl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(input, output string) (err error) {
+	if input == "" {
+		return fmt.Errorf("missing -input file")
+	}
+
+	var fh *os.File
+	if fh, err = os.Open(input); err != nil {
+		return
+	}
+	defer fh.Close()
+
+	var parser *clstrings.DomainParser
+	if parser, err = clstrings.LoadDomainParser(fh); err != nil {
+		return fmt.Errorf("%s: %w", input, err)
+	}
+
+	var icann, private int
+	for _, rule := range parser.Rules() {
+		if rule.Section == clstrings.DomainSectionPrivate {
+			private += 1
+		} else {
+			icann += 1
+		}
+	}
+	if icann == 0 {
+		return fmt.Errorf("%s: no ICANN rules found", input)
+	}
+
+	var out *os.File
+	if out, err = os.Create(output); err != nil {
+		return
+	}
+	if _, err = parser.WriteTo(out); err != nil {
+		_ = out.Close()
+		return
+	} else if err = out.Close(); err != nil {
+		return
+	}
+
+	fmt.Printf("wrote %d ICANN and %d private rules to %s\n", icann, private, output)
+	return
+}
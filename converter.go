@@ -0,0 +1,213 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// CommonInitialisms is the default list of acronyms registered, matching
+	// the golint list of common initialisms
+	CommonInitialisms = []string{
+		"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML",
+		"HTTP", "HTTPS", "ID", "IP", "JSON", "LHS", "QPS", "RAM", "RHS", "RPC",
+		"SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID",
+		"UUID", "URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS",
+	}
+
+	gCaseConverter = NewCaseConverter(CommonInitialisms...)
+)
+
+// RegisterAcronyms adds the acronyms given to the package-level CaseConverter
+// used by all the case converting functions
+func RegisterAcronyms(acronyms ...string) {
+	gCaseConverter.RegisterAcronyms(acronyms...)
+}
+
+// CaseConverter is an acronym-aware case converter. Words which match any of
+// the registered acronyms (case-insensitively) are rendered in the acronym's
+// registered form instead of being capitalized, for example with the "ID"
+// acronym registered, ToSpacedCamel("user_id") returns "User ID"
+//
+// CaseConverter is safe for concurrent use
+type CaseConverter struct {
+	digits   DigitRule
+	acronyms map[string]string
+	compiled *acronymSet
+	m        sync.RWMutex
+}
+
+// NewCaseConverter returns a new CaseConverter instance with the acronyms
+// given registered and using the DigitsJoin rule
+func NewCaseConverter(acronyms ...string) (c *CaseConverter) {
+	c = &CaseConverter{
+		digits:   DigitsJoin,
+		acronyms: make(map[string]string),
+	}
+	c.RegisterAcronyms(acronyms...)
+	return
+}
+
+// RegisterAcronyms adds the acronyms given to this CaseConverter
+func (c *CaseConverter) RegisterAcronyms(acronyms ...string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	for _, acronym := range acronyms {
+		if acronym != "" {
+			c.acronyms[strings.ToLower(acronym)] = acronym
+		}
+	}
+	list := make([]string, 0, len(c.acronyms))
+	for _, acronym := range c.acronyms {
+		list = append(list, acronym)
+	}
+	c.compiled = newAcronymSet(list...)
+}
+
+// SetDigits changes the DigitRule used when segmenting words
+func (c *CaseConverter) SetDigits(rule DigitRule) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.digits = rule
+}
+
+// Acronym returns the registered form of the `word` given, if `word` is an
+// acronym (case-insensitively). Plural forms of acronyms are also recognized,
+// for example "ids" returns "IDs"
+func (c *CaseConverter) Acronym(word string) (acronym string, ok bool) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	lower := strings.ToLower(word)
+	if acronym, ok = c.acronyms[lower]; ok {
+		return
+	} else if singular := strings.TrimSuffix(lower, "s"); singular != lower {
+		if acronym, ok = c.acronyms[singular]; ok {
+			acronym += "s"
+		}
+	}
+	return
+}
+
+// Words splits the text given into words, see Segmenter for the details
+func (c *CaseConverter) Words(text string) (words []string) {
+	c.m.RLock()
+	s := Segmenter{Digits: c.digits}
+	set := c.compiled
+	c.m.RUnlock()
+	return s.words(text, set)
+}
+
+// Capitalize returns the registered form of the `word` if it is an acronym
+// and otherwise upper-cases the first letter and lower-cases the rest
+func (c *CaseConverter) Capitalize(word string) (capitalized string) {
+	if acronym, ok := c.Acronym(word); ok {
+		return acronym
+	}
+	return capitalize(word)
+}
+
+// ToSpacedCamel is the acronym-aware version of the package-level
+// ToSpacedCamel function
+func (c *CaseConverter) ToSpacedCamel(text string) (spacedCamel string) {
+	spacedCamel = joinWords(c.Words(text), " ", c.Capitalize)
+	return
+}
+
+// ToDeepVar is the acronym-aware version of the package-level ToDeepVar
+// function
+func (c *CaseConverter) ToDeepVar(text string) (deepVar string) {
+	parts := strings.Split(strings.TrimPrefix(text, "."), ".")
+	for _, part := range parts {
+		deepVar += "." + joinWords(c.Words(part), "", c.Capitalize)
+	}
+	return
+}
+
+// ToTitleWords is the acronym-aware version of the package-level
+// ToTitleWords function. Words which are registered acronyms are replaced with
+// the registered form and all other words starting with a letter have that
+// letter title-cased
+func (c *CaseConverter) ToTitleWords(text string) (capitalized string) {
+	capitalized = replaceSpans(text, wordSpans(text), func(_ int, word string) (replaced string) {
+		return c.titleWord(word)
+	})
+	return
+}
+
+// titleWord returns the registered acronym form of `word` or `word` with
+// the first letter title-cased
+func (c *CaseConverter) titleWord(word string) (titled string) {
+	if acronym, ok := c.Acronym(word); ok {
+		return acronym
+	}
+	if r, size := utf8.DecodeRuneInString(word); unicode.IsLetter(r) {
+		return string(unicode.ToTitle(r)) + word[size:]
+	}
+	return word
+}
+
+// wordSpans returns the byte ranges of the words within `text`, where words
+// are runs of letters, numbers and marks. Apostrophes between a word and a
+// following letter are kept within the word, so that "don't" is one word
+func wordSpans(text string) (spans [][2]int) {
+	start := -1
+	for idx, r := range text {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) || (start > -1 && unicode.IsMark(r)) {
+			if start < 0 {
+				start = idx
+			}
+			continue
+		} else if start > -1 && isApostrophe(r) {
+			if next, _ := utf8.DecodeRuneInString(text[idx+utf8.RuneLen(r):]); unicode.IsLetter(next) {
+				continue
+			}
+		}
+		if start > -1 {
+			spans = append(spans, [2]int{start, idx})
+			start = -1
+		}
+	}
+	if start > -1 {
+		spans = append(spans, [2]int{start, len(text)})
+	}
+	return
+}
+
+// replaceSpans returns `text` with each of the `spans` replaced with the
+// result of calling `fn` with the span index and text, all bytes outside the
+// spans are copied as-is
+func replaceSpans(text string, spans [][2]int, fn func(idx int, word string) (replaced string)) (modified string) {
+	var buf strings.Builder
+	buf.Grow(len(text))
+	var last int
+	for idx, span := range spans {
+		buf.WriteString(text[last:span[0]])
+		buf.WriteString(fn(idx, text[span[0]:span[1]]))
+		last = span[1]
+	}
+	buf.WriteString(text[last:])
+	modified = buf.String()
+	return
+}
+
+// isApostrophe returns true if the rune given is an ASCII apostrophe or a
+// right single quotation mark
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’'
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConverter(t *testing.T) {
+	Convey("CaseConverter", t, func() {
+		c := NewCaseConverter()
+		So(c.ToSpacedCamel("user_id"), ShouldEqual, "User Id")
+		So(c.ToDeepVar(".api-url"), ShouldEqual, ".ApiUrl")
+		So(c.Words("APIURL"), ShouldEqual, []string{"APIURL"})
+
+		c.RegisterAcronyms("ID", "API", "URL", "OAuth")
+		So(c.ToSpacedCamel("user_id"), ShouldEqual, "User ID")
+		So(c.ToSpacedCamel("user_ids"), ShouldEqual, "User IDs")
+		So(c.ToSpacedCamel("oauth_token"), ShouldEqual, "OAuth Token")
+		So(c.ToDeepVar(".api-url"), ShouldEqual, ".APIURL")
+		So(c.ToDeepVar(".this-thing.user-id"), ShouldEqual, ".ThisThing.UserID")
+		So(c.ToTitleWords("the api url"), ShouldEqual, "The API URL")
+		So(c.ToTitleWords("the oauth-id"), ShouldEqual, "The OAuth-ID")
+		So(c.NameFromEmail("api@api.ess"), ShouldEqual, "Api @API")
+		So(c.Words("APIURL"), ShouldEqual, []string{"API", "URL"})
+
+		acronym, ok := c.Acronym("Url")
+		So(ok, ShouldBeTrue)
+		So(acronym, ShouldEqual, "URL")
+		acronym, ok = c.Acronym("urls")
+		So(ok, ShouldBeTrue)
+		So(acronym, ShouldEqual, "URLs")
+		acronym, ok = c.Acronym("nope")
+		So(ok, ShouldBeFalse)
+		So(acronym, ShouldEqual, "")
+
+		So(c.Capitalize("hello"), ShouldEqual, "Hello")
+		So(c.Capitalize("id"), ShouldEqual, "ID")
+
+		So(c.ToSpacedCamel("v2Api"), ShouldEqual, "V2 API")
+		c.SetDigits(DigitsSplit)
+		So(c.ToSpacedCamel("v2Api"), ShouldEqual, "V 2 API")
+	})
+
+	Convey("RegisterAcronyms", t, func() {
+		So(ToSpacedCamel("user_id"), ShouldEqual, "User ID")
+		So(ToDeepVar(".api-url"), ShouldEqual, ".APIURL")
+		So(ToTitleWords("the http server"), ShouldEqual, "The HTTP Server")
+		So(ToSpacedCamel("graphql_query"), ShouldEqual, "Graphql Query")
+		RegisterAcronyms("GraphQL")
+		So(ToSpacedCamel("graphql_query"), ShouldEqual, "GraphQL Query")
+		So(ToSpaced("GraphQLQuery"), ShouldEqual, "graphql query")
+	})
+}
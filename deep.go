@@ -0,0 +1,249 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DeepKey is a parsed go template variable or map key path, for example
+// both `.ThisThing.Variable` and `.this-thing.variable` are parsed into the
+// same two segments
+//
+// A DeepKey formats to the map key form with String and to the go template
+// variable form with Var. Both forms use the acronyms registered with
+// RegisterAcronyms, so that `.HTTPServer` formats as `.http-server` and back
+// to `.HTTPServer`. Round-tripping between the two forms is guaranteed for
+// segments made up of capitalized words with two or more letters and
+// registered acronyms
+type DeepKey []string
+
+// ParseDeepKey splits the text given into a DeepKey, any leading `$` and `.`
+// characters are removed with TrimTmplVar before splitting on the remaining
+// `.` separators. An empty DeepKey is returned for empty text
+func ParseDeepKey(text string) (key DeepKey) {
+	if trimmed := TrimTmplVar(text); trimmed != "" {
+		key = strings.Split(trimmed, ".")
+	}
+	return
+}
+
+// String returns the map key form of the DeepKey, like `.this-thing.variable`
+func (k DeepKey) String() string {
+	var buf strings.Builder
+	for _, segment := range k {
+		buf.WriteString(".")
+		buf.WriteString(toKebab(segment))
+	}
+	return buf.String()
+}
+
+// Var returns the go template variable form of the DeepKey, like
+// `.ThisThing.Variable`
+func (k DeepKey) Var() string {
+	var buf strings.Builder
+	for _, segment := range k {
+		buf.WriteString(".")
+		buf.WriteString(toPascal(segment))
+	}
+	return buf.String()
+}
+
+// Keys returns the map key form of each DeepKey segment
+func (k DeepKey) Keys() (keys []string) {
+	for _, segment := range k {
+		keys = append(keys, toKebab(segment))
+	}
+	return
+}
+
+// DeepGet uses ParseDeepKey to walk the `data` given, returning the value
+// found at the end of the DeepKey path. Map values are looked up with the
+// segment as given, followed by the map key form of the segment. Slice and
+// array values are indexed by segments which are integers and struct values
+// use exported fields which match the segment name in either the go template
+// variable or map key forms
+func DeepGet(data interface{}, key string) (value interface{}, found bool) {
+	current := reflect.ValueOf(data)
+	for _, segment := range ParseDeepKey(key) {
+		if current, found = deepStep(current, segment); !found {
+			return nil, false
+		}
+	}
+	if found = current.IsValid(); found && current.CanInterface() {
+		value = current.Interface()
+	}
+	return
+}
+
+// DeepSet uses ParseDeepKey to walk the `data` given, setting the `value` at
+// the end of the DeepKey path. Any missing map entries along the way are
+// created as new map[string]interface{} values using the map key form of the
+// segment. Slice elements can only be set when the index is within range and
+// struct fields can only be set when the struct is addressable, such as when
+// stored by pointer
+func DeepSet(data map[string]interface{}, key string, value interface{}) (err error) {
+	segments := ParseDeepKey(key)
+	if len(segments) == 0 {
+		return fmt.Errorf("%w: %q", ErrInvalidDeepKey, key)
+	}
+
+	current := reflect.ValueOf(data)
+	last := len(segments) - 1
+	for idx, segment := range segments {
+		if idx == last {
+			break
+		}
+		next, found := deepStep(current, segment)
+		if !found && current.Kind() == reflect.Map {
+			// create the missing map entry
+			next = reflect.ValueOf(map[string]interface{}{})
+			if err = deepAssign(current, segment, next); err != nil {
+				return fmt.Errorf("%w: %q", err, key)
+			}
+		} else if !found {
+			return fmt.Errorf("%w: %q", ErrDeepKeyNotFound, key)
+		}
+		current = next
+	}
+
+	if err = deepAssign(current, segments[last], reflect.ValueOf(value)); err != nil {
+		err = fmt.Errorf("%w: %q", err, key)
+	}
+	return
+}
+
+// deepIndirect dereferences any pointers and interfaces
+func deepIndirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// deepStep returns the value for the segment within the `current` value
+func deepStep(current reflect.Value, segment string) (next reflect.Value, found bool) {
+	current = deepIndirect(current)
+	switch current.Kind() {
+	case reflect.Map:
+		if current.Type().Key().Kind() != reflect.String {
+			return
+		}
+		for _, name := range deepNames(segment) {
+			k := reflect.ValueOf(name).Convert(current.Type().Key())
+			if next = current.MapIndex(k); next.IsValid() {
+				return next, true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if i, err := strconv.Atoi(segment); err == nil && i >= 0 && i < current.Len() {
+			return current.Index(i), true
+		}
+	case reflect.Struct:
+		if field, ok := deepField(current, segment); ok {
+			return field, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// deepAssign sets the `value` for the segment within the `current` value
+func deepAssign(current reflect.Value, segment string, value reflect.Value) (err error) {
+	current = deepIndirect(current)
+	switch current.Kind() {
+	case reflect.Map:
+		if current.Type().Key().Kind() != reflect.String {
+			return ErrDeepKeyNotSettable
+		}
+		name := toKebab(segment)
+		for _, candidate := range deepNames(segment) {
+			k := reflect.ValueOf(candidate).Convert(current.Type().Key())
+			if current.MapIndex(k).IsValid() {
+				name = candidate
+				break
+			}
+		}
+		if value, err = deepConvert(value, current.Type().Elem()); err == nil {
+			current.SetMapIndex(reflect.ValueOf(name).Convert(current.Type().Key()), value)
+		}
+		return
+	case reflect.Slice, reflect.Array:
+		if i, ee := strconv.Atoi(segment); ee == nil && i >= 0 && i < current.Len() {
+			if elem := current.Index(i); elem.CanSet() {
+				if value, err = deepConvert(value, elem.Type()); err == nil {
+					elem.Set(value)
+				}
+				return
+			}
+		}
+	case reflect.Struct:
+		if field, ok := deepField(current, segment); ok && field.CanSet() {
+			if value, err = deepConvert(value, field.Type()); err == nil {
+				field.Set(value)
+			}
+			return
+		}
+	}
+	return ErrDeepKeyNotSettable
+}
+
+// deepConvert returns the `value` as the type given, the zero value of the
+// type is used for invalid (nil) values and values of the same kind are
+// converted, such as named string types
+func deepConvert(value reflect.Value, t reflect.Type) (converted reflect.Value, err error) {
+	if !value.IsValid() {
+		return reflect.Zero(t), nil
+	} else if value.Type().AssignableTo(t) {
+		return value, nil
+	} else if value.Kind() == t.Kind() && value.Type().ConvertibleTo(t) {
+		return value.Convert(t), nil
+	}
+	return value, fmt.Errorf("%w: %v is not assignable to %v", ErrDeepKeyNotSettable, value.Type(), t)
+}
+
+// deepField returns the exported struct field which matches the `segment`,
+// fields promoted through nil embedded pointers are not found
+func deepField(current reflect.Value, segment string) (field reflect.Value, ok bool) {
+	t := current.Type()
+	if sf, present := t.FieldByName(toPascal(segment)); present && sf.IsExported() {
+		if field, err := current.FieldByIndexErr(sf.Index); err == nil {
+			return field, true
+		}
+		return reflect.Value{}, false
+	}
+	key := toKebab(segment)
+	for idx := 0; idx < t.NumField(); idx++ {
+		if sf := t.Field(idx); sf.IsExported() && toKebab(sf.Name) == key {
+			return current.Field(idx), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// deepNames returns the segment as given and the map key form of the segment
+// if it is different
+func deepNames(segment string) (names []string) {
+	names = append(names, segment)
+	if key := toKebab(segment); key != segment {
+		names = append(names, key)
+	}
+	return
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type deepTestStruct struct {
+	Name      string
+	ServerURL string
+	Items     []string
+	Nested    *deepTestStruct
+	private   string
+}
+
+type deepTestEmbedded struct {
+	*deepTestStruct
+	Other string
+}
+
+func TestDeep(t *testing.T) {
+
+	Convey("ParseDeepKey", t, func() {
+		So(ParseDeepKey(""), ShouldBeNil)
+		So(ParseDeepKey("$."), ShouldBeNil)
+		So(ParseDeepKey(".HTTPServer.ID"), ShouldEqual, DeepKey{"HTTPServer", "ID"})
+		So(ParseDeepKey("$.items.0.name"), ShouldEqual, DeepKey{"items", "0", "name"})
+
+		key := ParseDeepKey(".HTTPServer.UserIDs")
+		So(key.String(), ShouldEqual, ".http-server.user-ids")
+		So(key.Var(), ShouldEqual, ".HTTPServer.UserIDs")
+		So(key.Keys(), ShouldEqual, []string{"http-server", "user-ids"})
+		So(ParseDeepKey(key.String()).Var(), ShouldEqual, ".HTTPServer.UserIDs")
+
+		key = ParseDeepKey(".items.0.name")
+		So(key.String(), ShouldEqual, ".items.0.name")
+		So(key.Var(), ShouldEqual, ".Items.0.Name")
+	})
+
+	Convey("DeepKey round-trip", t, func() {
+		words := []string{"This", "Thing", "Server", "User", "Variable", "Go", "Enjin", "Entity", "Dog"}
+		words = append(words, CommonInitialisms...)
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 1000; i++ {
+			var name string
+			for j := r.Intn(3) + 1; j > 0; j-- {
+				name += words[r.Intn(len(words))]
+			}
+			v := "." + name
+			k := ParseDeepKey(v).String()
+			So(ParseDeepKey(k).Var(), ShouldEqual, v)
+			So(ToDeepVar(ToDeepKey(v)), ShouldEqual, v)
+			So(ToDeepKey(ToDeepVar(k)), ShouldEqual, k)
+		}
+	})
+
+	Convey("DeepGet", t, func() {
+		data := map[string]interface{}{
+			"this-thing": map[string]interface{}{
+				"variable": "value",
+				"items": []interface{}{
+					map[string]interface{}{"name": "first"},
+					map[string]interface{}{"name": "second"},
+				},
+			},
+			"Exact": 1,
+			"struct": &deepTestStruct{
+				Name:      "outer",
+				ServerURL: "https://go-enjin.org",
+				Items:     []string{"one", "two"},
+				Nested:    &deepTestStruct{Name: "inner"},
+				private:   "hidden",
+			},
+			"strings":  map[string]string{"key": "text"},
+			"nil":      nil,
+			"embedded": deepTestEmbedded{Other: "other"},
+			"filled":   deepTestEmbedded{deepTestStruct: &deepTestStruct{Name: "promoted"}},
+		}
+
+		checks := []struct {
+			key   string
+			value interface{}
+			found bool
+		}{
+			{".this-thing.variable", "value", true},
+			{".ThisThing.Variable", "value", true},
+			{".this-thing.items.1.name", "second", true},
+			{".ThisThing.Items.0.Name", "first", true},
+			{".this-thing.items.2.name", nil, false},
+			{".this-thing.items.nope", nil, false},
+			{".Exact", 1, true},
+			{".struct.name", "outer", true},
+			{".struct.server-url", "https://go-enjin.org", true},
+			{".Struct.ServerURL", "https://go-enjin.org", true},
+			{".struct.items.1", "two", true},
+			{".struct.nested.name", "inner", true},
+			{".struct.nested.nested.name", nil, false},
+			{".struct.private", nil, false},
+			{".strings.key", "text", true},
+			{".nil.thing", nil, false},
+			{".missing", nil, false},
+			{".embedded.other", "other", true},
+			{".embedded.name", nil, false},
+			{".embedded.nested.name", nil, false},
+			{".filled.name", "promoted", true},
+		}
+		for _, check := range checks {
+			value, found := DeepGet(data, check.key)
+			So(found, ShouldEqual, check.found)
+			So(value, ShouldEqual, check.value)
+		}
+	})
+
+	Convey("DeepSet", t, func() {
+		inner := &deepTestStruct{Items: []string{"one", "two"}}
+		data := map[string]interface{}{
+			"list":   []interface{}{"zero", "one"},
+			"struct": inner,
+			"value":  deepTestStruct{},
+		}
+
+		So(DeepSet(data, ".ThisThing.Variable", "value"), ShouldBeNil)
+		So(data["this-thing"], ShouldEqual, map[string]interface{}{"variable": "value"})
+		So(DeepSet(data, ".this-thing.variable", "changed"), ShouldBeNil)
+		So(data["this-thing"], ShouldEqual, map[string]interface{}{"variable": "changed"})
+
+		So(DeepSet(data, ".list.1", "uno"), ShouldBeNil)
+		So(data["list"], ShouldEqual, []interface{}{"zero", "uno"})
+
+		So(DeepSet(data, ".struct.server-url", "https://go-enjin.org"), ShouldBeNil)
+		So(inner.ServerURL, ShouldEqual, "https://go-enjin.org")
+		So(DeepSet(data, ".struct.items.0", "uno"), ShouldBeNil)
+		So(inner.Items, ShouldEqual, []string{"uno", "two"})
+		So(DeepSet(data, ".struct.nested", nil), ShouldBeNil)
+		So(inner.Nested, ShouldBeNil)
+
+		So(errors.Is(DeepSet(data, "", 1), ErrInvalidDeepKey), ShouldBeTrue)
+		So(errors.Is(DeepSet(data, ".list.2", 1), ErrDeepKeyNotSettable), ShouldBeTrue)
+		So(errors.Is(DeepSet(data, ".list.5.name", 1), ErrDeepKeyNotFound), ShouldBeTrue)
+		So(errors.Is(DeepSet(data, ".value.name", "nope"), ErrDeepKeyNotSettable), ShouldBeTrue)
+		So(errors.Is(DeepSet(data, ".struct.name", 10), ErrDeepKeyNotSettable), ShouldBeTrue)
+
+		type named string
+		So(DeepSet(data, ".struct.name", named("named")), ShouldBeNil)
+		So(inner.Name, ShouldEqual, "named")
+
+		embedded := &deepTestEmbedded{}
+		data["embedded"] = embedded
+		So(errors.Is(DeepSet(data, ".embedded.name", "nope"), ErrDeepKeyNotSettable), ShouldBeTrue)
+		So(errors.Is(DeepSet(data, ".embedded.nested.name", "nope"), ErrDeepKeyNotFound), ShouldBeTrue)
+		So(DeepSet(data, ".embedded.other", "other"), ShouldBeNil)
+		So(embedded.Other, ShouldEqual, "other")
+		embedded.deepTestStruct = &deepTestStruct{}
+		So(DeepSet(data, ".embedded.name", "promoted"), ShouldBeNil)
+		So(embedded.Name, ShouldEqual, "promoted")
+	})
+
+}
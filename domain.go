@@ -0,0 +1,518 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/go-corelibs/slices"
+	"golang.org/x/net/idna"
+)
+
+// PublicSuffixListFile is the path to the public suffix list snapshot within
+// the PublicSuffixListFS
+const PublicSuffixListFile = "psl/public_suffix_list.dat"
+
+var (
+	// PublicSuffixListFS is the embedded snapshot of the public suffix list
+	// used by the DefaultDomainParser, see the cmd/update-psl documentation
+	// for updating it
+	//
+	//go:embed psl/public_suffix_list.dat
+	PublicSuffixListFS embed.FS
+
+	gDomainParser     *DomainParser
+	gDomainParserOnce sync.Once
+
+	// gDomainIDNA is the IDNA2008 lookup profile, without the STD3 rules so
+	// that service labels like "_dmarc" are allowed
+	gDomainIDNA = idna.New(
+		idna.MapForLookup(),
+		idna.Transitional(false),
+		idna.StrictDomainName(false),
+		idna.BidiRule(),
+	)
+
+	// gDomainScriptSets are the combinations of scripts which are allowed
+	// within a single label, see the "Highly Restrictive" level of
+	// https://www.unicode.org/reports/tr39/#Restriction_Level_Detection
+	gDomainScriptSets = [][]*unicode.RangeTable{
+		{unicode.Latin, unicode.Han, unicode.Hiragana, unicode.Katakana},
+		{unicode.Latin, unicode.Han, unicode.Bopomofo},
+		{unicode.Latin, unicode.Han, unicode.Hangul},
+	}
+)
+
+const (
+	pslBeginICANN   = "===BEGIN ICANN DOMAINS==="
+	pslEndICANN     = "===END ICANN DOMAINS==="
+	pslBeginPrivate = "===BEGIN PRIVATE DOMAINS==="
+	pslEndPrivate   = "===END PRIVATE DOMAINS==="
+	pslVersion      = "VERSION:"
+	pslCommit       = "COMMIT:"
+)
+
+// DomainSection identifies the section of the public suffix list a
+// DomainRule is from
+type DomainSection uint8
+
+const (
+	// DomainSectionNone is used for the implicit "*" rule which applies when
+	// no other rules match
+	DomainSectionNone DomainSection = iota
+	// DomainSectionICANN is used for the rules in the ICANN DOMAINS section
+	DomainSectionICANN
+	// DomainSectionPrivate is used for the rules in the PRIVATE DOMAINS
+	// section
+	DomainSectionPrivate
+)
+
+// String returns the name of the DomainSection, like "icann" or "private"
+func (s DomainSection) String() string {
+	switch s {
+	case DomainSectionICANN:
+		return "icann"
+	case DomainSectionPrivate:
+		return "private"
+	}
+	return "none"
+}
+
+// DomainRule is a single public suffix list rule
+type DomainRule struct {
+	// Rule is the rule as written in the list, in ASCII (punycode) form, like
+	// "co.uk", "*.ck" or "!www.ck"
+	Rule string
+	// Section is the list section the Rule is from
+	Section DomainSection
+}
+
+// IsWildcard returns true if the DomainRule is a wildcard rule, like "*.ck"
+func (r DomainRule) IsWildcard() bool {
+	return strings.HasPrefix(r.Rule, "*.")
+}
+
+// IsException returns true if the DomainRule is an exception rule, like
+// "!www.ck"
+func (r DomainRule) IsException() bool {
+	return strings.HasPrefix(r.Rule, "!")
+}
+
+// Domain is the structured result of ParseDomain, with both the ASCII and the
+// Unicode forms of the domain name
+type Domain struct {
+	// ASCII is the IDNA2008 ASCII form of the domain, where internationalized
+	// labels are punycode encoded, like "xn--bcher-kva.example.de"
+	ASCII DomainName
+	// Unicode is the IDNA2008 Unicode form of the domain, like
+	// "bücher.example.de"
+	Unicode DomainName
+	// Rule is the public suffix list rule which matched the TLD
+	Rule DomainRule
+	// MixedScript is the list of Unicode labels which mix letters from more
+	// than one script, like "pаypal" spelled with a Cyrillic "а"
+	MixedScript []string
+}
+
+// IsMixedScript returns true if any of the Domain labels mix letters from more
+// than one script, which is a common sign of a homograph attack
+func (d Domain) IsMixedScript() bool {
+	return len(d.MixedScript) > 0
+}
+
+// String returns the Unicode form of the Domain
+func (d Domain) String() string {
+	return d.Unicode.Host
+}
+
+// DomainName is one form of a Domain, split into its component parts in the
+// same way as ParseDomainName
+type DomainName struct {
+	// Host is the complete domain name, lower-cased and without any trailing
+	// period
+	Host string
+	// TLD is the public suffix of the Host, like "co.uk"
+	TLD string
+	// Name is the label before the TLD, like "example", and is empty when the
+	// Host is itself a public suffix
+	Name string
+	// Subdomains are the labels before the Name, in reverse order
+	Subdomains []string
+}
+
+// DomainParser finds the public suffixes of domain names using the rules of
+// a public suffix list (https://publicsuffix.org). DomainParser instances are
+// read-only once loaded and are safe for concurrent use
+type DomainParser struct {
+	version string
+	commit  string
+	rules   []DomainRule
+	lookup  map[string]DomainRule
+	icann   bool
+}
+
+// DefaultDomainParser returns the DomainParser for the embedded public suffix
+// list snapshot, which is loaded on first use
+func DefaultDomainParser() (p *DomainParser) {
+	gDomainParserOnce.Do(func() {
+		var err error
+		if gDomainParser, err = LoadDomainParserFS(PublicSuffixListFS, PublicSuffixListFile); err != nil {
+			// the embedded snapshot is checked by the tests, fallback to the
+			// implicit "*" rule for everything
+			gDomainParser = &DomainParser{lookup: make(map[string]DomainRule)}
+		}
+	})
+	return gDomainParser
+}
+
+// LoadDomainParserFS is a convenience wrapper around LoadDomainParser for
+// reading the named file from the filesystem given, such as an embed.FS
+func LoadDomainParserFS(fsys fs.FS, name string) (p *DomainParser, err error) {
+	var fh fs.File
+	if fh, err = fsys.Open(name); err != nil {
+		return
+	}
+	defer fh.Close()
+	return LoadDomainParser(fh)
+}
+
+// LoadDomainParser reads a public suffix list from the io.Reader given. Rules
+// are the first white space separated field on each line, comments start with
+// `//` and the ICANN and PRIVATE sections are marked with the standard
+// "===BEGIN ICANN DOMAINS===" style comments. Rules outside either section
+// are ICANN rules. Unicode rules are converted to their ASCII form
+//
+// LoadDomainParser returns an error wrapping ErrInvalidDomainRule for rules
+// which are not valid and ErrNoDomainRules when there are no rules at all
+func LoadDomainParser(r io.Reader) (p *DomainParser, err error) {
+	p = &DomainParser{lookup: make(map[string]DomainRule)}
+	section := DomainSectionICANN
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if comment, ok := strings.CutPrefix(text, "//"); ok {
+			comment = strings.TrimSpace(comment)
+			switch {
+			case comment == pslBeginICANN:
+				section = DomainSectionICANN
+			case comment == pslBeginPrivate:
+				section = DomainSectionPrivate
+			case comment == pslEndICANN, comment == pslEndPrivate:
+				section = DomainSectionICANN
+			case strings.HasPrefix(comment, pslVersion) && p.version == "":
+				p.version = strings.TrimSpace(comment[len(pslVersion):])
+			case strings.HasPrefix(comment, pslCommit) && p.commit == "":
+				p.commit = strings.TrimSpace(comment[len(pslCommit):])
+			}
+			continue
+		} else if fields := strings.Fields(text); len(fields) > 0 {
+			var rule string
+			if rule, err = parseDomainRule(fields[0]); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %q", ErrInvalidDomainRule, line, fields[0])
+			} else if _, present := p.lookup[rule]; !present {
+				p.lookup[rule] = DomainRule{Rule: rule, Section: section}
+				p.rules = append(p.rules, p.lookup[rule])
+			}
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	} else if len(p.rules) == 0 {
+		return nil, ErrNoDomainRules
+	}
+	return
+}
+
+// Version returns the VERSION comment of the public suffix list, if present
+func (p *DomainParser) Version() (version string) {
+	return p.version
+}
+
+// Commit returns the COMMIT comment of the public suffix list, if present
+func (p *DomainParser) Commit() (commit string) {
+	return p.commit
+}
+
+// Rules returns a copy of all the rules in the order they were loaded
+func (p *DomainParser) Rules() (rules []DomainRule) {
+	rules = make([]DomainRule, len(p.rules))
+	copy(rules, p.rules)
+	return
+}
+
+// ICANNOnly returns a copy of this DomainParser which ignores all the rules
+// in the PRIVATE section, for example "blogspot.com" is a private rule and
+// using ICANNOnly, the public suffix of "example.blogspot.com" is "com"
+func (p *DomainParser) ICANNOnly() (icann *DomainParser) {
+	clone := *p
+	clone.icann = true
+	return &clone
+}
+
+// PublicSuffix returns the public suffix of the `domain` given along with the
+// rule which matched. The `domain` is lower-cased and any trailing period is
+// removed. When no rules match, the implicit "*" rule is used and the public
+// suffix is the last label of the `domain`, with a DomainSectionNone rule
+func (p *DomainParser) PublicSuffix(domain string) (suffix string, rule DomainRule) {
+	labels := strings.Split(normalizeDomain(domain), ".")
+	if len(labels) == 1 && labels[0] == "" {
+		return "", DomainRule{Rule: "*"}
+	}
+
+	// exception rules take priority
+	for idx := range labels {
+		if found, ok := p.match("!" + strings.Join(labels[idx:], ".")); ok {
+			return strings.Join(labels[idx+1:], "."), found
+		}
+	}
+
+	// otherwise the longest normal or wildcard rule
+	for idx := range labels {
+		candidate := strings.Join(labels[idx:], ".")
+		if found, ok := p.match(candidate); ok {
+			return candidate, found
+		} else if idx+1 < len(labels) {
+			if found, ok = p.match("*." + strings.Join(labels[idx+1:], ".")); ok {
+				return candidate, found
+			}
+		}
+	}
+
+	return labels[len(labels)-1], DomainRule{Rule: "*"}
+}
+
+// ParseDomainName is like the package-level ParseDomainName except that the
+// rules of this DomainParser are used
+func (p *DomainParser) ParseDomainName(input string) (tld, name string, subdomains []string) {
+	if domain, err := p.ParseDomain(input); (err == nil || errors.Is(err, ErrMixedScriptDomain)) && domain.Unicode.Name != "" {
+		return domain.Unicode.TLD, domain.Unicode.Name, domain.Unicode.Subdomains
+	}
+	// fallback to always return something
+	list := SplitSortReversed(input, ".")
+	if count := len(list); count > 0 {
+		if tld = list[0]; count > 1 {
+			if name = list[1]; count > 2 {
+				subdomains = list[2:]
+			}
+		}
+	}
+	return
+}
+
+// ParseDomain is like the package-level ParseDomain except that the rules of
+// this DomainParser are used
+func (p *DomainParser) ParseDomain(input string) (domain Domain, err error) {
+	host := strings.TrimSuffix(strings.TrimSpace(input), ".")
+	if host == "" {
+		return Domain{}, fmt.Errorf("%w: %q", ErrInvalidDomain, input)
+	}
+
+	var ascii string
+	if ascii, err = gDomainIDNA.ToASCII(host); err != nil {
+		return Domain{}, fmt.Errorf("%w: %q: %v", ErrInvalidDomain, input, err)
+	}
+	labels := strings.Split(ascii, ".")
+	unicodes := make([]string, len(labels))
+	for idx, label := range labels {
+		if !isDomainLabel(label) {
+			return Domain{}, fmt.Errorf("%w: %q", ErrInvalidDomain, input)
+		} else if unicodes[idx], err = gDomainIDNA.ToUnicode(label); err != nil {
+			return Domain{}, fmt.Errorf("%w: %q: %v", ErrInvalidDomain, input, err)
+		} else if isMixedScript(unicodes[idx]) {
+			domain.MixedScript = append(domain.MixedScript, unicodes[idx])
+		}
+	}
+
+	var suffix string
+	suffix, domain.Rule = p.PublicSuffix(ascii)
+	size := len(labels) - strings.Count(suffix, ".") - 1
+	domain.ASCII = newDomainName(labels, size)
+	domain.Unicode = newDomainName(unicodes, size)
+
+	if len(domain.MixedScript) > 0 {
+		err = fmt.Errorf("%w: %q", ErrMixedScriptDomain, strings.Join(domain.MixedScript, ", "))
+	}
+	return
+}
+
+// ParseDomain converts the `input` to its IDNA2008 ASCII and Unicode forms
+// and splits both into their component parts, using the public suffix list
+// to find the TLD. ParseDomain returns an error wrapping ErrInvalidDomain
+// when the `input` is not a valid domain name
+//
+// Labels which mix scripts, like Latin and Cyrillic letters, are listed in
+// the Domain.MixedScript and ParseDomain returns an error wrapping
+// ErrMixedScriptDomain along with the complete Domain, so that callers can
+// choose to reject or only flag these domains
+//
+// ParseDomain is a wrapper around DomainParser.ParseDomain using the
+// DefaultDomainParser
+func ParseDomain(input string) (domain Domain, err error) {
+	return DefaultDomainParser().ParseDomain(input)
+}
+
+// WriteTo writes the rules of this DomainParser to the io.Writer given, in the
+// public suffix list format which LoadDomainParser reads. The MPL license
+// header, the VERSION and COMMIT comments and the ICANN and PRIVATE section
+// markers are included, all other comments are not
+func (p *DomainParser) WriteTo(w io.Writer) (n int64, err error) {
+	var buf strings.Builder
+	buf.WriteString("// This Source Code Form is subject to the terms of the Mozilla Public\n")
+	buf.WriteString("// License, v. 2.0. If a copy of the MPL was not distributed with this\n")
+	buf.WriteString("// file, You can obtain one at https://mozilla.org/MPL/2.0/.\n")
+	if p.version != "" || p.commit != "" {
+		buf.WriteString("//\n")
+		if p.version != "" {
+			buf.WriteString("// " + pslVersion + " " + p.version + "\n")
+		}
+		if p.commit != "" {
+			buf.WriteString("// " + pslCommit + " " + p.commit + "\n")
+		}
+	}
+	for _, section := range []DomainSection{DomainSectionICANN, DomainSectionPrivate} {
+		begin, end := pslBeginICANN, pslEndICANN
+		if section == DomainSectionPrivate {
+			begin, end = pslBeginPrivate, pslEndPrivate
+		}
+		buf.WriteString("\n// " + begin + "\n")
+		for _, rule := range p.rules {
+			if rule.Section == section {
+				buf.WriteString(rule.Rule + "\n")
+			}
+		}
+		buf.WriteString("// " + end + "\n")
+	}
+	written, err := io.WriteString(w, buf.String())
+	return int64(written), err
+}
+
+// match looks up the `rule` given, ignoring private rules when this
+// DomainParser is ICANNOnly
+func (p *DomainParser) match(rule string) (found DomainRule, ok bool) {
+	if found, ok = p.lookup[rule]; ok && p.icann && found.Section == DomainSectionPrivate {
+		return DomainRule{}, false
+	}
+	return
+}
+
+// parseDomainRule validates and normalizes a single public suffix list rule
+func parseDomainRule(text string) (rule string, err error) {
+	var prefix string
+	if strings.HasPrefix(text, "!") {
+		prefix, text = "!", text[1:]
+	} else if strings.HasPrefix(text, "*.") {
+		prefix, text = "*.", text[2:]
+	}
+	if text == "" || strings.Contains(text, "*") || strings.Contains(text, "!") {
+		return "", ErrInvalidDomainRule
+	}
+	labels := strings.Split(text, ".")
+	for idx, label := range labels {
+		if label == "" {
+			return "", ErrInvalidDomainRule
+		} else if ascii, ee := idna.ToASCII(label); ee == nil {
+			labels[idx] = ascii
+		}
+	}
+	rule = prefix + strings.ToLower(strings.Join(labels, "."))
+	return
+}
+
+// normalizeDomain converts the `domain` to its IDNA2008 ASCII form and
+// removes any trailing period, domains which cannot be converted are only
+// lower-cased
+func normalizeDomain(domain string) (normalized string) {
+	normalized = strings.TrimSuffix(strings.TrimSpace(domain), ".")
+	if ascii, err := gDomainIDNA.ToASCII(normalized); err == nil {
+		return ascii
+	}
+	return strings.ToLower(normalized)
+}
+
+// isDomainLabel returns true if the ASCII `label` is not empty and has only
+// lower case letters, digits, dashes and underscores
+func isDomainLabel(label string) bool {
+	return label != "" && strings.IndexFunc(label, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_'
+	}) < 0
+}
+
+// newDomainName returns the DomainName of the `labels` given, where the first
+// `size` labels are the subdomains and the name
+func newDomainName(labels []string, size int) (name DomainName) {
+	name.Host = strings.Join(labels, ".")
+	if size <= 0 {
+		name.TLD = name.Host
+		return
+	}
+	name.TLD = strings.Join(labels[size:], ".")
+	name.Name = labels[size-1]
+	for idx := size - 2; idx >= 0; idx-- {
+		name.Subdomains = append(name.Subdomains, labels[idx])
+	}
+	return
+}
+
+// isMixedScript returns true if the `label` has letters from more than one
+// script, other than the combinations used in Chinese, Japanese and Korean
+// domains. Letters from the Common and Inherited scripts, like digits and
+// combining marks, are ignored
+func isMixedScript(label string) (mixed bool) {
+	var scripts []*unicode.RangeTable
+	for _, r := range label {
+		if r < 0x80 {
+			if unicode.IsLetter(r) && !slices.Present(unicode.Latin, scripts...) {
+				scripts = append(scripts, unicode.Latin)
+			}
+			continue
+		} else if unicode.In(r, unicode.Common, unicode.Inherited) {
+			continue
+		}
+		for _, table := range unicode.Scripts {
+			if unicode.Is(table, r) {
+				if !slices.Present(table, scripts...) {
+					scripts = append(scripts, table)
+				}
+				break
+			}
+		}
+	}
+	if len(scripts) <= 1 {
+		return false
+	}
+	for _, allowed := range gDomainScriptSets {
+		mixed = false
+		for _, script := range scripts {
+			if !slices.Present(script, allowed...) {
+				mixed = true
+				break
+			}
+		}
+		if !mixed {
+			return
+		}
+	}
+	return
+}
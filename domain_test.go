@@ -0,0 +1,354 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const gDomainTestingList = `// comments are ignored
+// VERSION: 2024-01-01_00-00-00_UTC
+// COMMIT: abcdef
+
+// ===BEGIN ICANN DOMAINS===
+com
+uk
+co.uk
+*.ck
+!www.ck
+公司.cn
+cn
+// ===END ICANN DOMAINS===
+
+// ===BEGIN PRIVATE DOMAINS===
+blogspot.com extra fields are ignored
+// ===END PRIVATE DOMAINS===
+`
+
+func TestDomainParser(t *testing.T) {
+	Convey("LoadDomainParser", t, func() {
+		p, err := LoadDomainParser(strings.NewReader(gDomainTestingList))
+		So(err, ShouldBeNil)
+		So(p.Version(), ShouldEqual, "2024-01-01_00-00-00_UTC")
+		So(p.Commit(), ShouldEqual, "abcdef")
+		So(p.Rules(), ShouldResemble, []DomainRule{
+			{"com", DomainSectionICANN},
+			{"uk", DomainSectionICANN},
+			{"co.uk", DomainSectionICANN},
+			{"*.ck", DomainSectionICANN},
+			{"!www.ck", DomainSectionICANN},
+			{"xn--55qx5d.cn", DomainSectionICANN},
+			{"cn", DomainSectionICANN},
+			{"blogspot.com", DomainSectionPrivate},
+		})
+
+		_, err = LoadDomainParser(strings.NewReader("// nothing\n"))
+		So(errors.Is(err, ErrNoDomainRules), ShouldBeTrue)
+		for _, invalid := range []string{"a..b", "a.*.b", "*.", "!", "a!b", ".com"} {
+			_, err = LoadDomainParser(strings.NewReader("com\n" + invalid + "\n"))
+			So(errors.Is(err, ErrInvalidDomainRule), ShouldBeTrue)
+			So(err.Error(), ShouldContainSubstring, "line 2")
+		}
+	})
+
+	Convey("LoadDomainParserFS", t, func() {
+		fsys := fstest.MapFS{"list.dat": {Data: []byte(gDomainTestingList)}}
+		p, err := LoadDomainParserFS(fsys, "list.dat")
+		So(err, ShouldBeNil)
+		So(p.Rules(), ShouldHaveLength, 8)
+		_, err = LoadDomainParserFS(fsys, "missing.dat")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("PublicSuffix", t, func() {
+		p, err := LoadDomainParser(strings.NewReader(gDomainTestingList))
+		So(err, ShouldBeNil)
+
+		checks := []struct {
+			domain, suffix, rule string
+			section              DomainSection
+		}{
+			{"", "", "*", DomainSectionNone},
+			{"example.com", "com", "com", DomainSectionICANN},
+			{"WWW.Example.COM.", "com", "com", DomainSectionICANN},
+			{"example.co.uk", "co.uk", "co.uk", DomainSectionICANN},
+			{"example.uk", "uk", "uk", DomainSectionICANN},
+			{"example.blogspot.com", "blogspot.com", "blogspot.com", DomainSectionPrivate},
+			{"a.b.test.ck", "test.ck", "*.ck", DomainSectionICANN},
+			{"www.ck", "ck", "!www.ck", DomainSectionICANN},
+			{"a.www.ck", "ck", "!www.ck", DomainSectionICANN},
+			{"shishi.xn--55qx5d.cn", "xn--55qx5d.cn", "xn--55qx5d.cn", DomainSectionICANN},
+			{"example.unknown", "unknown", "*", DomainSectionNone},
+		}
+		for _, check := range checks {
+			suffix, rule := p.PublicSuffix(check.domain)
+			So(suffix, ShouldEqual, check.suffix)
+			So(rule.Rule, ShouldEqual, check.rule)
+			So(rule.Section, ShouldEqual, check.section)
+		}
+
+		suffix, rule := p.PublicSuffix("a.b.test.ck")
+		So(rule.IsWildcard(), ShouldBeTrue)
+		So(rule.IsException(), ShouldBeFalse)
+		suffix, rule = p.PublicSuffix("www.ck")
+		So(rule.IsException(), ShouldBeTrue)
+
+		suffix, rule = p.ICANNOnly().PublicSuffix("example.blogspot.com")
+		So(suffix, ShouldEqual, "com")
+		So(rule.Section, ShouldEqual, DomainSectionICANN)
+		suffix, _ = p.PublicSuffix("example.blogspot.com")
+		So(suffix, ShouldEqual, "blogspot.com")
+
+		So(DomainSectionNone.String(), ShouldEqual, "none")
+		So(DomainSectionICANN.String(), ShouldEqual, "icann")
+		So(DomainSectionPrivate.String(), ShouldEqual, "private")
+	})
+
+	Convey("WriteTo", t, func() {
+		p, err := LoadDomainParser(strings.NewReader(gDomainTestingList))
+		So(err, ShouldBeNil)
+		var buf bytes.Buffer
+		_, err = p.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldContainSubstring, "// VERSION: 2024-01-01_00-00-00_UTC\n")
+		So(buf.String(), ShouldContainSubstring, "// ===BEGIN PRIVATE DOMAINS===\nblogspot.com\n// ===END PRIVATE DOMAINS===\n")
+		again, err := LoadDomainParser(&buf)
+		So(err, ShouldBeNil)
+		So(again.Rules(), ShouldResemble, p.Rules())
+		So(again.Version(), ShouldEqual, p.Version())
+		So(again.Commit(), ShouldEqual, p.Commit())
+	})
+
+	Convey("DefaultDomainParser", t, func() {
+		p := DefaultDomainParser()
+		So(p, ShouldEqual, DefaultDomainParser())
+		So(len(p.Rules()), ShouldBeGreaterThan, 9000)
+		So(p.Version(), ShouldNotBeEmpty)
+
+		// the embedded snapshot is the WriteTo output of itself
+		var buf bytes.Buffer
+		_, err := p.WriteTo(&buf)
+		So(err, ShouldBeNil)
+		snapshot, err := PublicSuffixListFS.ReadFile(PublicSuffixListFile)
+		So(err, ShouldBeNil)
+		So(buf.String(), ShouldEqual, string(snapshot))
+
+		// the ASCII test vectors from https://publicsuffix.org/list/
+		vectors := []struct {
+			domain, registrable string
+		}{
+			{"COM", ""},
+			{"example.COM", "example.com"},
+			{"WwW.example.COM", "example.com"},
+			{".com", ""},
+			{".example", ""},
+			{".example.com", ""},
+			{"example", ""},
+			{"example.example", "example.example"},
+			{"b.example.example", "example.example"},
+			{"biz", ""},
+			{"domain.biz", "domain.biz"},
+			{"a.b.domain.biz", "domain.biz"},
+			{"uk.com", ""},
+			{"example.uk.com", "example.uk.com"},
+			{"a.b.example.uk.com", "example.uk.com"},
+			{"test.ac", "test.ac"},
+			{"mm", ""},
+			{"c.mm", ""},
+			{"b.c.mm", "b.c.mm"},
+			{"a.b.c.mm", "b.c.mm"},
+			{"jp", ""},
+			{"test.jp", "test.jp"},
+			{"www.test.jp", "test.jp"},
+			{"ac.jp", ""},
+			{"test.ac.jp", "test.ac.jp"},
+			{"kyoto.jp", ""},
+			{"test.kyoto.jp", "test.kyoto.jp"},
+			{"ide.kyoto.jp", ""},
+			{"b.ide.kyoto.jp", "b.ide.kyoto.jp"},
+			{"a.b.ide.kyoto.jp", "b.ide.kyoto.jp"},
+			{"c.kobe.jp", ""},
+			{"b.c.kobe.jp", "b.c.kobe.jp"},
+			{"a.b.c.kobe.jp", "b.c.kobe.jp"},
+			{"city.kobe.jp", "city.kobe.jp"},
+			{"www.city.kobe.jp", "city.kobe.jp"},
+			{"ck", ""},
+			{"test.ck", ""},
+			{"b.test.ck", "b.test.ck"},
+			{"a.b.test.ck", "b.test.ck"},
+			{"www.ck", "www.ck"},
+			{"www.www.ck", "www.ck"},
+			{"us", ""},
+			{"test.us", "test.us"},
+			{"www.test.us", "test.us"},
+			{"ak.us", ""},
+			{"test.ak.us", "test.ak.us"},
+			{"www.test.ak.us", "test.ak.us"},
+			{"k12.ak.us", ""},
+			{"test.k12.ak.us", "test.k12.ak.us"},
+			{"www.test.k12.ak.us", "test.k12.ak.us"},
+			{"xn--85x722f.com.cn", "xn--85x722f.com.cn"},
+			{"xn--85x722f.xn--55qx5d.cn", "xn--85x722f.xn--55qx5d.cn"},
+			{"www.xn--85x722f.xn--55qx5d.cn", "xn--85x722f.xn--55qx5d.cn"},
+			{"shishi.xn--55qx5d.cn", "shishi.xn--55qx5d.cn"},
+			{"xn--55qx5d.cn", ""},
+			{"xn--85x722f.xn--fiqs8s", "xn--85x722f.xn--fiqs8s"},
+			{"www.xn--85x722f.xn--fiqs8s", "xn--85x722f.xn--fiqs8s"},
+			{"shishi.xn--fiqs8s", "shishi.xn--fiqs8s"},
+			{"xn--fiqs8s", ""},
+			{"食狮.com.cn", "xn--85x722f.com.cn"},
+			{"食狮.公司.cn", "xn--85x722f.xn--55qx5d.cn"},
+			{"www.食狮.公司.cn", "xn--85x722f.xn--55qx5d.cn"},
+			{"shishi.公司.cn", "shishi.xn--55qx5d.cn"},
+			{"公司.cn", ""},
+			{"食狮.中国", "xn--85x722f.xn--fiqs8s"},
+			{"www.食狮.中国", "xn--85x722f.xn--fiqs8s"},
+			{"中国", ""},
+		}
+		for _, vector := range vectors {
+			var registrable string
+			if !strings.HasPrefix(vector.domain, ".") {
+				suffix, _ := p.PublicSuffix(vector.domain)
+				if domain := normalizeDomain(vector.domain); suffix != domain {
+					labels := strings.Split(strings.TrimSuffix(domain, "."+suffix), ".")
+					registrable = labels[len(labels)-1] + "." + suffix
+				}
+			}
+			So(registrable, ShouldEqual, vector.registrable)
+		}
+	})
+
+	Convey("DomainParser.ParseDomainName", t, func() {
+		tld, name, subs := DefaultDomainParser().ParseDomainName("www.example.co.uk")
+		So(tld, ShouldEqual, "co.uk")
+		So(name, ShouldEqual, "example")
+		So(subs, ShouldEqual, []string{"www"})
+
+		tld, name, subs = DefaultDomainParser().ParseDomainName("a.b.example.blogspot.com")
+		So(tld, ShouldEqual, "blogspot.com")
+		So(name, ShouldEqual, "example")
+		So(subs, ShouldEqual, []string{"b", "a"})
+
+		tld, name, subs = DefaultDomainParser().ICANNOnly().ParseDomainName("a.b.example.blogspot.com")
+		So(tld, ShouldEqual, "com")
+		So(name, ShouldEqual, "blogspot")
+		So(subs, ShouldEqual, []string{"example", "b", "a"})
+
+		tld, name, subs = DefaultDomainParser().ParseDomainName("co.uk")
+		So(tld, ShouldEqual, "uk")
+		So(name, ShouldEqual, "co")
+		So(subs, ShouldBeNil)
+	})
+
+	Convey("ParseDomain", t, func() {
+		checks := []struct {
+			input   string
+			ascii   DomainName
+			unicode DomainName
+			mixed   []string
+		}{
+			{
+				"bücher.example.de",
+				DomainName{"xn--bcher-kva.example.de", "de", "example", []string{"xn--bcher-kva"}},
+				DomainName{"bücher.example.de", "de", "example", []string{"bücher"}},
+				nil,
+			},
+			{
+				"XN--BCHER-KVA.Example.DE.",
+				DomainName{"xn--bcher-kva.example.de", "de", "example", []string{"xn--bcher-kva"}},
+				DomainName{"bücher.example.de", "de", "example", []string{"bücher"}},
+				nil,
+			},
+			{
+				"www.食狮.公司.cn",
+				DomainName{"www.xn--85x722f.xn--55qx5d.cn", "xn--55qx5d.cn", "xn--85x722f", []string{"www"}},
+				DomainName{"www.食狮.公司.cn", "公司.cn", "食狮", []string{"www"}},
+				nil,
+			},
+			{
+				"Straße.de",
+				DomainName{"xn--strae-oqa.de", "de", "xn--strae-oqa", nil},
+				DomainName{"straße.de", "de", "straße", nil},
+				nil,
+			},
+			{
+				"_dmarc.example.com",
+				DomainName{"_dmarc.example.com", "com", "example", []string{"_dmarc"}},
+				DomainName{"_dmarc.example.com", "com", "example", []string{"_dmarc"}},
+				nil,
+			},
+			{
+				"co.uk",
+				DomainName{"co.uk", "co.uk", "", nil},
+				DomainName{"co.uk", "co.uk", "", nil},
+				nil,
+			},
+			{
+				"東京tokyo.jp",
+				DomainName{"xn--tokyo-w91hq39l.jp", "jp", "xn--tokyo-w91hq39l", nil},
+				DomainName{"東京tokyo.jp", "jp", "東京tokyo", nil},
+				nil,
+			},
+			{
+				// whole script confusables are not mixed
+				"xn--80ak6aa92e.com",
+				DomainName{"xn--80ak6aa92e.com", "com", "xn--80ak6aa92e", nil},
+				DomainName{"аррӏе.com", "com", "аррӏе", nil},
+				nil,
+			},
+			{
+				// cyrillic "р" and "а"
+				"www.раypal.com",
+				DomainName{"www.xn--ypal-43d9g.com", "com", "xn--ypal-43d9g", []string{"www"}},
+				DomainName{"www.раypal.com", "com", "раypal", []string{"www"}},
+				[]string{"раypal"},
+			},
+			{
+				"αβγ.abcδ.gr",
+				DomainName{"xn--mxacd.xn--abc-2xc.gr", "gr", "xn--abc-2xc", []string{"xn--mxacd"}},
+				DomainName{"αβγ.abcδ.gr", "gr", "abcδ", []string{"αβγ"}},
+				[]string{"abcδ"},
+			},
+		}
+		for _, check := range checks {
+			domain, err := ParseDomain(check.input)
+			So(domain.ASCII, ShouldResemble, check.ascii)
+			So(domain.Unicode, ShouldResemble, check.unicode)
+			So(domain.MixedScript, ShouldResemble, check.mixed)
+			So(domain.IsMixedScript(), ShouldEqual, check.mixed != nil)
+			So(domain.String(), ShouldEqual, check.unicode.Host)
+			if check.mixed != nil {
+				So(errors.Is(err, ErrMixedScriptDomain), ShouldBeTrue)
+			} else {
+				So(err, ShouldBeNil)
+			}
+		}
+
+		domain, err := ParseDomain("example.blogspot.com")
+		So(err, ShouldBeNil)
+		So(domain.Rule, ShouldResemble, DomainRule{"blogspot.com", DomainSectionPrivate})
+
+		for _, invalid := range []string{"", " . ", "a..b", ".example.com", "xn--a.com", "exa mple.com", "a/b.com", "a@b.com", "-a.com"} {
+			_, err = ParseDomain(invalid)
+			So(errors.Is(err, ErrInvalidDomain), ShouldBeTrue)
+		}
+	})
+}
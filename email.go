@@ -0,0 +1,323 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// maxEmailLocal is the maximum number of bytes in the local part of an
+	// email address
+	maxEmailLocal = 64
+	// maxEmailAddress is the maximum number of bytes in an email address
+	maxEmailAddress = 254
+)
+
+// gEmailScanner only recognizes the RFC 5322 double-quoted strings, so that
+// apostrophes within display names like "O'Brien" are not quotes
+var gEmailScanner = &Scanner{
+	Quotes: []QuotePair{{Start: '"', End: '"'}},
+	Escape: '\\',
+}
+
+// gEmailProviders are the canonicalization rules of the email providers which
+// ignore periods in the local part or have more than one domain
+var gEmailProviders = map[string]emailProvider{
+	"gmail.com":      {domain: "gmail.com", ignoreDots: true},
+	"googlemail.com": {domain: "gmail.com", ignoreDots: true},
+}
+
+// gEmailRoles are the local parts of common role accounts, which are compared
+// in lower case without any digits or separators
+var gEmailRoles = newWordSet(
+	"abuse accounts admin administrator billing careers contact donotreply",
+	"enquiries feedback hello help hostmaster hr info inquiries jobs legal",
+	"mail mailerdaemon marketing news newsletter noreply notifications",
+	"office postmaster press privacy root sales security service support",
+	"team webmaster",
+)
+
+// gNameOnsets are the pairs of consonants which commonly start a first name,
+// used to tell "chris" from "jsmith"
+var gNameOnsets = newWordSet(
+	"bl br ch cl cr dr dw fl fr gh gl gn gr kh kl kn kr ph pl pr ps rh",
+	"sc sh sk sl sm sn sp sq st sv sw sz th tr ts tw vl vr wh wr zh",
+	"bj dj dm fj hj kj ll mc mb mk ml mw ng nk nt nz sr tl tj vs zb zd",
+)
+
+// emailProvider describes how an email provider canonicalizes addresses
+type emailProvider struct {
+	// domain is the canonical domain of the provider
+	domain string
+	// ignoreDots is true when periods in the local part are ignored
+	ignoreDots bool
+}
+
+// Email is the structured result of ParseEmail
+type Email struct {
+	// Display is the display name of the address, like "Jane Doe" from
+	// `"Jane Doe" <jane@example.com>`
+	Display string
+	// Local is the local part of the address, as given and including any
+	// plus-addressing tag, like "jane.doe+news"
+	Local string
+	// User is the Local part without any plus-addressing tag, like "jane.doe"
+	User string
+	// Tag is the plus-addressing tag without the plus, like "news"
+	Tag string
+	// Domain is the parsed domain of the address
+	Domain Domain
+}
+
+// ParseEmail parses a single RFC 5322 email address, either a bare address
+// like "jane@example.com" or an address with a display name like
+// `"Jane Doe" <jane@example.com>` or "Jane Doe <jane@example.com>". The text
+// is normalized to NFC and internationalized local parts and domains are
+// supported. Quoted local parts are unquoted and are not split into a User
+// and Tag
+//
+// ParseEmail returns an error wrapping ErrInvalidEmail when the `addr` is not
+// a valid email address, including when the domain has no registrable name
+// (like "localhost") or is a domain literal (like "[127.0.0.1]"). When the
+// domain has mixed script labels, ParseEmail returns the complete Email with
+// an error wrapping ErrMixedScriptDomain, see ParseDomain
+func ParseEmail(addr string) (email Email, err error) {
+	text := norm.NFC.String(strings.TrimSpace(addr))
+
+	if before, after, found := gEmailScanner.Scan(text, "<"); found {
+		var ok bool
+		if text, ok = strings.CutSuffix(after, ">"); !ok {
+			return Email{}, fmt.Errorf("%w: %q", ErrInvalidEmail, addr)
+		}
+		email.Display = unquoteEmailPhrase(before)
+	}
+
+	at := strings.LastIndex(text, "@")
+	if at < 0 || len(text) > maxEmailAddress {
+		return Email{}, fmt.Errorf("%w: %q", ErrInvalidEmail, addr)
+	}
+	local, host := text[:at], text[at+1:]
+
+	if strings.HasPrefix(local, `"`) {
+		if before, quoted, after, found := gEmailScanner.ScanQuote(local); found && before == "" && after == "" && quoted != "" {
+			email.Local, email.User = quoted, quoted
+		}
+	} else if isEmailLocal(local) {
+		email.Local = local
+		email.User, email.Tag, _ = strings.Cut(local, "+")
+	}
+	if email.User == "" || len(local) > maxEmailLocal {
+		return Email{}, fmt.Errorf("%w: %q", ErrInvalidEmail, addr)
+	}
+
+	if email.Domain, err = ParseDomain(host); err != nil && !errors.Is(err, ErrMixedScriptDomain) {
+		return Email{}, fmt.Errorf("%w: %q", ErrInvalidEmail, addr)
+	} else if email.Domain.Unicode.Name == "" {
+		return Email{}, fmt.Errorf("%w: %q", ErrInvalidEmail, addr)
+	}
+	return
+}
+
+// Address returns the email address without the Display name, with the
+// Unicode form of the Domain
+func (e Email) Address() (address string) {
+	local := e.Local
+	if !isEmailLocal(local) {
+		local = strconv.Quote(local)
+	}
+	return local + "@" + e.Domain.Unicode.Host
+}
+
+// Canonical returns the lower-cased email address without the Display name
+// or plus-addressing Tag, with any provider-specific rules applied. For
+// example, "Jane.Doe+news@GoogleMail.com" and "janedoe@gmail.com" have the
+// same Canonical address. Canonical addresses are intended for detecting
+// duplicate accounts and are not always deliverable
+func (e Email) Canonical() (canonical string) {
+	user, domain := strings.ToLower(e.User), e.Domain.Unicode.Host
+	if provider, ok := gEmailProviders[domain]; ok {
+		if domain = provider.domain; provider.ignoreDots {
+			user = strings.ReplaceAll(user, ".", "")
+		}
+	}
+	if !isEmailLocal(user) {
+		user = strconv.Quote(user)
+	}
+	return user + "@" + domain
+}
+
+// String returns the Address, with the Display name when present, like
+// `"Jane Doe" <jane@example.com>`
+func (e Email) String() string {
+	if e.Display == "" {
+		return e.Address()
+	}
+	return strconv.Quote(e.Display) + " <" + e.Address() + ">"
+}
+
+// GuessNameFromEmail guesses the Name of the person with the email address
+// given, along with a confidence between zero and one
+//
+// The display name is used when present, like `"Jane Doe" <jd@example.com>`,
+// otherwise the local part is used without any plus-addressing tag or digits.
+// Local parts are split on periods and underscores, like "first.last" and
+// "first_last", on dashes when there are no periods or underscores, like
+// "first-last", and on case changes, like "firstLast". A single word which
+// starts with an unlikely pair of consonants is guessed to be an initial and
+// a last name, like "jsmith", and otherwise is only a first name. Neither of
+// these single word guesses score more than 0.4
+//
+// Role accounts, like "info@", "noreply@" and "admin@", and email addresses
+// which cannot be parsed, return an empty Name with zero confidence
+func GuessNameFromEmail(email string) (name Name, confidence float64) {
+	parsed, err := ParseEmail(email)
+	if err != nil && !errors.Is(err, ErrMixedScriptDomain) {
+		return Name{}, 0
+	} else if parsed.Display != "" {
+		if name = ParseName(parsed.Display); name.First != "" || name.Last != "" {
+			return name, 0.95
+		}
+	}
+
+	if _, role := gEmailRoles[strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, parsed.User)]; role {
+		return Name{}, 0
+	}
+
+	local := strings.Map(func(r rune) rune {
+		if unicode.IsNumber(r) {
+			return -1
+		}
+		return r
+	}, parsed.User)
+
+	var words []string
+	switch {
+	case strings.ContainsAny(local, "._"):
+		words, confidence = strings.FieldsFunc(local, func(r rune) bool {
+			return r == '.' || r == '_'
+		}), 0.9
+	case strings.Contains(local, "-"):
+		words, confidence = strings.Split(local, "-"), 0.9
+	default:
+		words, confidence = Words(local), 0.8
+	}
+
+	var tokens []string
+	for _, word := range words {
+		if token := nameToken(word, len(tokens) > 0); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+
+	switch count := len(tokens); {
+	case count == 0:
+		return Name{}, 0
+	case count == 1:
+		if runes := []rune(tokens[0]); len(runes) > 3 && isNameInitialed(runes) {
+			tokens, confidence = []string{string(runes[:1]), capitalize(string(runes[1:]))}, 0.4
+		} else if len(runes) > 1 {
+			confidence = 0.3
+		} else {
+			confidence = 0.1
+		}
+	case utf8.RuneCountInString(tokens[count-1]) == 1:
+		// a last initial, like "john.s"
+		confidence -= 0.3
+	case utf8.RuneCountInString(tokens[0]) == 1:
+		// a first initial, like "j.smith"
+		confidence -= 0.2
+	case count > 3:
+		confidence -= 0.2
+	}
+	if local != parsed.User {
+		confidence -= 0.1
+	}
+
+	name = ParseName(strings.Join(tokens, " "))
+	return
+}
+
+// nameToken returns the `word` given without any characters other than
+// letters and dashes, capitalized unless it is a surname particle and is
+// `notFirst`
+func nameToken(word string, notFirst bool) (token string) {
+	word = strings.Trim(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsMark(r) || r == '-' {
+			return r
+		}
+		return -1
+	}, word), "-")
+	if notFirst && isNameParticle(word) {
+		return strings.ToLower(word)
+	}
+	parts := strings.Split(word, "-")
+	for idx, part := range parts {
+		parts[idx] = capitalize(part)
+	}
+	return strings.Join(parts, "-")
+}
+
+// isNameInitialed returns true if the first two letters of the `runes` given
+// are consonants which do not commonly start a first name
+func isNameInitialed(runes []rune) bool {
+	first, second := unicode.ToLower(runes[0]), unicode.ToLower(runes[1])
+	if first > unicode.MaxASCII || second > unicode.MaxASCII {
+		return false
+	} else if strings.ContainsRune("aeiouy", first) || strings.ContainsRune("aeiouy", second) {
+		return false
+	}
+	_, onset := gNameOnsets[string([]rune{first, second})]
+	return !onset
+}
+
+// unquoteEmailPhrase unquotes all the quoted words within the `phrase` given,
+// for example `"Jane" Doe` becomes "Jane Doe"
+func unquoteEmailPhrase(phrase string) (unquoted string) {
+	var buf strings.Builder
+	for {
+		before, quoted, after, found := gEmailScanner.ScanQuote(phrase)
+		buf.WriteString(before)
+		if !found {
+			break
+		}
+		buf.WriteString(quoted)
+		phrase = after
+	}
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
+
+// isEmailLocal returns true if the `local` part is a valid unquoted RFC 5322
+// dot-atom, allowing for internationalized letters and digits (RFC 6531)
+func isEmailLocal(local string) bool {
+	if local == "" || local[0] == '.' || local[len(local)-1] == '.' || strings.Contains(local, "..") {
+		return false
+	}
+	return strings.IndexFunc(local, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsMark(r) && !strings.ContainsRune(".!#$%&'*+-/=?^_`{|}~", r)
+	}) < 0
+}
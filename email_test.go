@@ -0,0 +1,208 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestEmail(t *testing.T) {
+	Convey("ParseEmail", t, func() {
+		checks := []struct {
+			input     string
+			display   string
+			local     string
+			user      string
+			tag       string
+			host      string
+			address   string
+			canonical string
+			output    string
+		}{
+			{
+				"jane@example.com",
+				"", "jane", "jane", "", "example.com",
+				"jane@example.com", "jane@example.com", "jane@example.com",
+			},
+			{
+				`"Jane Doe" <Jane.Doe+News@Example.COM>`,
+				"Jane Doe", "Jane.Doe+News", "Jane.Doe", "News", "example.com",
+				"Jane.Doe+News@example.com", "jane.doe@example.com", `"Jane Doe" <Jane.Doe+News@example.com>`,
+			},
+			{
+				` Jane Doe  <jane@example.com> `,
+				"Jane Doe", "jane", "jane", "", "example.com",
+				"jane@example.com", "jane@example.com", `"Jane Doe" <jane@example.com>`,
+			},
+			{
+				`"Doe, Jane \"JD\"" <jane@example.com>`,
+				`Doe, Jane "JD"`, "jane", "jane", "", "example.com",
+				"jane@example.com", "jane@example.com", `"Doe, Jane \"JD\"" <jane@example.com>`,
+			},
+			{
+				`"Jane <Work>" <jane@example.com>`,
+				"Jane <Work>", "jane", "jane", "", "example.com",
+				"jane@example.com", "jane@example.com", `"Jane <Work>" <jane@example.com>`,
+			},
+			{
+				`"Jane"  Doe <jane@example.com>`,
+				"Jane Doe", "jane", "jane", "", "example.com",
+				"jane@example.com", "jane@example.com", `"Jane Doe" <jane@example.com>`,
+			},
+			{
+				"O'Brien <obrien@example.com>",
+				"O'Brien", "obrien", "obrien", "", "example.com",
+				"obrien@example.com", "obrien@example.com", `"O'Brien" <obrien@example.com>`,
+			},
+			{
+				"<jane@example.com>",
+				"", "jane", "jane", "", "example.com",
+				"jane@example.com", "jane@example.com", "jane@example.com",
+			},
+			{
+				"J.A.N.E+spam+more@GoogleMail.com",
+				"", "J.A.N.E+spam+more", "J.A.N.E", "spam+more", "googlemail.com",
+				"J.A.N.E+spam+more@googlemail.com", "jane@gmail.com", "J.A.N.E+spam+more@googlemail.com",
+			},
+			{
+				"jane.doe@gmail.com",
+				"", "jane.doe", "jane.doe", "", "gmail.com",
+				"jane.doe@gmail.com", "janedoe@gmail.com", "jane.doe@gmail.com",
+			},
+			{
+				`"jane doe"@example.com`,
+				"", "jane doe", "jane doe", "", "example.com",
+				`"jane doe"@example.com`, `"jane doe"@example.com`, `"jane doe"@example.com`,
+			},
+			{
+				`"jane@home"@example.com`,
+				"", "jane@home", "jane@home", "", "example.com",
+				`"jane@home"@example.com`, `"jane@home"@example.com`, `"jane@home"@example.com`,
+			},
+			{
+				"jürgen@xn--mnchen-3ya.de",
+				"", "jürgen", "jürgen", "", "münchen.de",
+				"jürgen@münchen.de", "jürgen@münchen.de", "jürgen@münchen.de",
+			},
+			{
+				"jürgen@München.de",
+				"", "jürgen", "jürgen", "", "münchen.de",
+				"jürgen@münchen.de", "jürgen@münchen.de", "jürgen@münchen.de",
+			},
+			{
+				"+tag@example.co.uk",
+				"", "", "", "", "",
+				"", "", "",
+			},
+		}
+		for _, check := range checks {
+			email, err := ParseEmail(check.input)
+			if check.user == "" {
+				So(errors.Is(err, ErrInvalidEmail), ShouldBeTrue)
+				continue
+			}
+			So(err, ShouldBeNil)
+			So(email.Display, ShouldEqual, check.display)
+			So(email.Local, ShouldEqual, check.local)
+			So(email.User, ShouldEqual, check.user)
+			So(email.Tag, ShouldEqual, check.tag)
+			So(email.Domain.Unicode.Host, ShouldEqual, check.host)
+			So(email.Address(), ShouldEqual, check.address)
+			So(email.Canonical(), ShouldEqual, check.canonical)
+			So(email.String(), ShouldEqual, check.output)
+		}
+
+		email, err := ParseEmail("jane@www.example.co.uk")
+		So(err, ShouldBeNil)
+		So(email.Domain.Unicode.TLD, ShouldEqual, "co.uk")
+		So(email.Domain.Unicode.Name, ShouldEqual, "example")
+		So(email.Domain.Unicode.Subdomains, ShouldEqual, []string{"www"})
+
+		email, err = ParseEmail("jane@раypal.com")
+		So(errors.Is(err, ErrMixedScriptDomain), ShouldBeTrue)
+		So(email.User, ShouldEqual, "jane")
+		So(email.Domain.IsMixedScript(), ShouldBeTrue)
+
+		for _, invalid := range []string{
+			"",
+			"jane",
+			"jane@",
+			"@example.com",
+			"jane@localhost",
+			"jane@co.uk",
+			"jane@[127.0.0.1]",
+			"jane@exa mple.com",
+			".jane@example.com",
+			"jane.@example.com",
+			"ja..ne@example.com",
+			"ja ne@example.com",
+			`""@example.com`,
+			`"jane"doe@example.com`,
+			"Jane <jane@example.com",
+			"jane@example.com>",
+			"a123456789012345678901234567890123456789012345678901234567890123456789@example.com",
+		} {
+			_, err = ParseEmail(invalid)
+			So(errors.Is(err, ErrInvalidEmail), ShouldBeTrue)
+		}
+	})
+
+	Convey("GuessNameFromEmail", t, func() {
+		checks := []struct {
+			input      string
+			name       Name
+			confidence float64
+		}{
+			{`"Dr. Jane Doe" <jd@example.com>`, Name{Title: "Dr.", First: "Jane", Last: "Doe"}, 0.95},
+			{"first.last+news@example.com", Name{First: "First", Last: "Last"}, 0.9},
+			{"jane_doe@example.com", Name{First: "Jane", Last: "Doe"}, 0.9},
+			{"jane-doe@example.com", Name{First: "Jane", Last: "Doe"}, 0.9},
+			{"jean-luc.picard@example.com", Name{First: "Jean-Luc", Last: "Picard"}, 0.9},
+			{"mary.ann.smith@example.com", Name{First: "Mary", Middle: "Ann", Last: "Smith"}, 0.9},
+			{"vincent.van.gogh@example.com", Name{First: "Vincent", Last: "van Gogh"}, 0.9},
+			{"john.smith.jr@example.com", Name{First: "John", Last: "Smith", Suffix: "Jr"}, 0.9},
+			{"janeDoe@example.com", Name{First: "Jane", Last: "Doe"}, 0.8},
+			{"JaneDoe@example.com", Name{First: "Jane", Last: "Doe"}, 0.8},
+			{"john.smith85@example.com", Name{First: "John", Last: "Smith"}, 0.8},
+			{"j.smith@example.com", Name{First: "J", Last: "Smith"}, 0.7},
+			{"john.s@example.com", Name{First: "John", Last: "S"}, 0.6},
+			{"jsmith@acme.com", Name{First: "J", Last: "Smith"}, 0.4},
+			{"jsmith1985@acme.com", Name{First: "J", Last: "Smith"}, 0.3},
+			{"nguyen@example.com", Name{First: "Nguyen"}, 0.3},
+			{"dmitri@example.com", Name{First: "Dmitri"}, 0.3},
+			{"lloyd@example.com", Name{First: "Lloyd"}, 0.3},
+			{"bjorn@example.com", Name{First: "Bjorn"}, 0.3},
+			{"mcdonald@example.com", Name{First: "Mcdonald"}, 0.3},
+			{"ngozi@example.com", Name{First: "Ngozi"}, 0.3},
+			{"chris@example.com", Name{First: "Chris"}, 0.3},
+			{"yvonne@example.com", Name{First: "Yvonne"}, 0.3},
+			{"j@example.com", Name{First: "J"}, 0.1},
+			{"info@example.com", Name{}, 0},
+			{"No-Reply@example.com", Name{}, 0},
+			{"do.not.reply@example.com", Name{}, 0},
+			{"admin2@example.com", Name{}, 0},
+			{"12345@example.com", Name{}, 0},
+			{"not an email", Name{}, 0},
+		}
+		for _, check := range checks {
+			name, confidence := GuessNameFromEmail(check.input)
+			So(name, ShouldResemble, check.name)
+			So(confidence, ShouldAlmostEqual, check.confidence)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrInvalidDeepKey is returned when a DeepKey has no segments
+	ErrInvalidDeepKey = errors.New("invalid deep key")
+	// ErrDeepKeyNotFound is returned when a DeepKey path cannot be walked
+	ErrDeepKeyNotFound = errors.New("deep key not found")
+	// ErrDeepKeyNotSettable is returned when a DeepKey value cannot be set
+	ErrDeepKeyNotSettable = errors.New("deep key not settable")
+	// ErrInvalidDomainRule is returned when a public suffix list rule is not
+	// valid
+	ErrInvalidDomainRule = errors.New("invalid domain rule")
+	// ErrNoDomainRules is returned when a public suffix list has no rules
+	ErrNoDomainRules = errors.New("no domain rules")
+	// ErrInvalidDomain is returned when a domain name cannot be parsed
+	ErrInvalidDomain = errors.New("invalid domain")
+	// ErrMixedScriptDomain is returned when a domain name has labels which mix
+	// letters from more than one script
+	ErrMixedScriptDomain = errors.New("mixed script domain")
+	// ErrInvalidHost is returned when a host cannot be parsed
+	ErrInvalidHost = errors.New("invalid host")
+	// ErrNoRegistrableDomain is returned when a host has no registrable domain
+	ErrNoRegistrableDomain = errors.New("no registrable domain")
+	// ErrInvalidDomainPattern is returned when a domain pattern cannot be
+	// parsed
+	ErrInvalidDomainPattern = errors.New("invalid domain pattern")
+	// ErrInvalidEmail is returned when an email address cannot be parsed
+	ErrInvalidEmail = errors.New("invalid email")
+)
+
+// ScanErrorKind describes the type of problem a ScanError is reporting
+type ScanErrorKind uint8
+
+const (
+	// ScanUnterminatedQuote indicates a quotation which is never closed
+	ScanUnterminatedQuote ScanErrorKind = iota + 1
+	// ScanTrailingEscape indicates an escape rune at the very end of the input
+	ScanTrailingEscape
+	// ScanInvalidEscape indicates an escape sequence which cannot be unquoted
+	ScanInvalidEscape
+	// ScanUnbalancedMarker indicates a carve start marker which is never
+	// balanced by an end marker
+	ScanUnbalancedMarker
+)
+
+// String returns a short description of the ScanErrorKind
+func (k ScanErrorKind) String() string {
+	switch k {
+	case ScanUnterminatedQuote:
+		return "unterminated quote"
+	case ScanTrailingEscape:
+		return "trailing escape"
+	case ScanInvalidEscape:
+		return "invalid escape sequence"
+	case ScanUnbalancedMarker:
+		return "unbalanced marker"
+	}
+	return "unknown scan error"
+}
+
+// ScanError is the error type returned by the Scan functions which report
+// problems with the input text
+type ScanError struct {
+	// Kind is the type of problem detected
+	Kind ScanErrorKind
+	// Rune is the offending rune found at Offset
+	Rune rune
+	// Quote is the starting rune of the quotation enclosing the problem, zero
+	// if the problem is not within quoted text
+	Quote rune
+	// Marker is the carve marker which is unbalanced, only used with the
+	// ScanUnbalancedMarker Kind
+	Marker string
+	// Offset is the byte offset of the offending Rune in the input
+	Offset int
+	// Line is the one-based line number of the offending Rune
+	Line int
+	// Column is the one-based rune count of the offending Rune on its Line
+	Column int
+}
+
+// newScanError constructs a ScanError for the rune found at the given byte
+// offset within `src`
+func newScanError(kind ScanErrorKind, src string, offset int, quote rune) (err *ScanError) {
+	r, _ := utf8.DecodeRuneInString(src[offset:])
+	line, column := lineColumn(src, offset)
+	err = &ScanError{
+		Kind:   kind,
+		Rune:   r,
+		Quote:  quote,
+		Offset: offset,
+		Line:   line,
+		Column: column,
+	}
+	return
+}
+
+// Error returns a human-readable description of the ScanError, for example:
+//
+//	unterminated double quote starting at line 3, column 17
+func (e *ScanError) Error() string {
+	switch e.Kind {
+	case ScanUnterminatedQuote:
+		return fmt.Sprintf("unterminated %s starting at line %d, column %d", quoteName(e.Rune), e.Line, e.Column)
+	case ScanTrailingEscape:
+		return fmt.Sprintf("trailing escape %q at line %d, column %d", e.Rune, e.Line, e.Column)
+	case ScanInvalidEscape:
+		return fmt.Sprintf("invalid escape sequence within %s at line %d, column %d", quoteName(e.Quote), e.Line, e.Column)
+	case ScanUnbalancedMarker:
+		return fmt.Sprintf("unbalanced %q starting at line %d, column %d", e.Marker, e.Line, e.Column)
+	}
+	return fmt.Sprintf("%s at line %d, column %d", e.Kind, e.Line, e.Column)
+}
+
+// quoteName returns a human-readable name for the quotation rune given
+func quoteName(r rune) (name string) {
+	switch r {
+	case '"':
+		return "double quote"
+	case '\'':
+		return "single quote"
+	case '`':
+		return "backtick quote"
+	}
+	return string(r) + " quote"
+}
+
+// lineColumn returns the one-based line and column numbers of the byte offset
+// within `src`, with the column counted in runes
+func lineColumn(src string, offset int) (line, column int) {
+	head := src[:offset]
+	line = strings.Count(head, "\n") + 1
+	if last := strings.LastIndexByte(head, '\n'); last > -1 {
+		head = head[last+1:]
+	}
+	column = utf8.RuneCountInString(head) + 1
+	return
+}
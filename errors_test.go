@@ -0,0 +1,53 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestErrors(t *testing.T) {
+	Convey("ScanErrorKind", t, func() {
+		So(ScanUnterminatedQuote.String(), ShouldEqual, "unterminated quote")
+		So(ScanTrailingEscape.String(), ShouldEqual, "trailing escape")
+		So(ScanInvalidEscape.String(), ShouldEqual, "invalid escape sequence")
+		So(ScanUnbalancedMarker.String(), ShouldEqual, "unbalanced marker")
+		So(ScanErrorKind(0).String(), ShouldEqual, "unknown scan error")
+	})
+
+	Convey("ScanError", t, func() {
+		src := "one\ntwo\n  ünï \"three"
+		err := newScanError(ScanUnterminatedQuote, src, 16, '"')
+		So(err.Rune, ShouldEqual, '"')
+		So(err.Offset, ShouldEqual, 16)
+		So(err.Line, ShouldEqual, 3)
+		So(err.Column, ShouldEqual, 7)
+		So(err.Error(), ShouldEqual, "unterminated double quote starting at line 3, column 7")
+
+		err = newScanError(ScanTrailingEscape, `one\`, 3, 0)
+		So(err.Error(), ShouldEqual, `trailing escape '\\' at line 1, column 4`)
+
+		err = newScanError(ScanInvalidEscape, `'\q'`, 1, '\'')
+		So(err.Error(), ShouldEqual, "invalid escape sequence within single quote at line 1, column 2")
+
+		err = newScanError(ScanUnterminatedQuote, "«one", 0, '«')
+		So(err.Error(), ShouldEqual, "unterminated « quote starting at line 1, column 1")
+
+		err = &ScanError{Line: 1, Column: 1}
+		So(err.Error(), ShouldEqual, "unknown scan error at line 1, column 1")
+	})
+}
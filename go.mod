@@ -3,20 +3,16 @@ module github.com/go-corelibs/strings
 go 1.21.6
 
 require (
-	github.com/amonsat/fullname_parser v0.0.0-20180221140204-0879740fa92c
 	github.com/go-corelibs/slices v1.4.0
-	github.com/iancoleman/strcase v0.3.0
 	github.com/maruel/natural v1.1.1
 	github.com/smartystreets/goconvey v1.8.1
-	github.com/weppos/publicsuffix-go v0.30.1
+	golang.org/x/net v0.12.0
+	golang.org/x/text v0.11.0
 )
 
 require (
 	github.com/gopherjs/gopherjs v1.17.2 // indirect
+	github.com/iancoleman/strcase v0.3.0 // indirect
 	github.com/jtolds/gls v4.20.0+incompatible // indirect
-	github.com/sirupsen/logrus v1.8.1 // indirect
 	github.com/smarty/assertions v1.15.0 // indirect
-	golang.org/x/net v0.12.0 // indirect
-	golang.org/x/sys v0.10.0 // indirect
-	golang.org/x/text v0.11.0 // indirect
 )
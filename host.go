@@ -0,0 +1,298 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Host is the structured result of ParseHost
+type Host struct {
+	// Name is the normalized host name, which is either the IDNA2008 ASCII
+	// form of the Domain or the IP address, without any brackets
+	Name string
+	// Port is the port number given with the host, if any
+	Port string
+	// IP is the address of IP literal hosts, see IsIP
+	IP netip.Addr
+	// Domain is the parsed domain of hosts which are not IP literals
+	Domain Domain
+}
+
+// IsIP returns true if the Host is an IPv4 or IPv6 literal
+func (h Host) IsIP() bool {
+	return h.IP.IsValid()
+}
+
+// Registrable returns the registrable domain of the Host, which is the public
+// suffix plus one more label, in ASCII form, like "example.co.uk". IP literals
+// and hosts which are themselves public suffixes have no registrable domain
+// and an empty string is returned
+func (h Host) Registrable() (domain string) {
+	if h.IsIP() || h.Domain.ASCII.Name == "" {
+		return ""
+	}
+	return h.Domain.ASCII.Name + "." + h.Domain.ASCII.TLD
+}
+
+// String returns the Host Name with the Port, if any. IPv6 addresses are
+// enclosed in brackets when there is a Port
+func (h Host) String() string {
+	if h.Port == "" {
+		return h.Name
+	} else if h.IP.Is6() {
+		return "[" + h.Name + "]:" + h.Port
+	}
+	return h.Name + ":" + h.Port
+}
+
+// DomainPattern is a host name pattern, see ParseDomainPattern
+type DomainPattern struct {
+	parser   *DomainParser
+	wildcard string
+	base     Host
+}
+
+// Match returns true if the host given matches the DomainPattern. Ports are
+// only compared when the DomainPattern has a port
+func (d *DomainPattern) Match(input string) (matched bool) {
+	host, ok := d.parser.parseSiteHost(input)
+	if !ok || (d.base.Port != "" && host.Port != d.base.Port) {
+		return false
+	} else if d.base.IsIP() {
+		return host.IP == d.base.IP
+	}
+	switch prefix, found := strings.CutSuffix(host.Name, "."+d.base.Name); d.wildcard {
+	case "*":
+		return found && !strings.Contains(prefix, ".")
+	case "**":
+		return found
+	}
+	return host.Name == d.base.Name
+}
+
+// String returns the normalized form of the DomainPattern, like
+// "*.xn--bcher-kva.example"
+func (d *DomainPattern) String() string {
+	if d.wildcard == "" {
+		return d.base.String()
+	}
+	return d.wildcard + "." + d.base.String()
+}
+
+// ParseDomainPattern is like the package-level ParseDomainPattern except that
+// the rules of this DomainParser are used
+func (p *DomainParser) ParseDomainPattern(pattern string) (d *DomainPattern, err error) {
+	d = &DomainPattern{parser: p}
+	text := strings.TrimSpace(pattern)
+	if rest, ok := strings.CutPrefix(text, "**."); ok {
+		d.wildcard, text = "**", rest
+	} else if rest, ok = strings.CutPrefix(text, "*."); ok {
+		d.wildcard, text = "*", rest
+	}
+
+	if strings.Contains(text, "://") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidDomainPattern, pattern)
+	} else if d.base, err = p.ParseHost(text); err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidDomainPattern, pattern)
+	} else if d.wildcard != "" && d.base.Registrable() == "" {
+		// wildcards must not span public suffix boundaries, like "*.co.uk"
+		return nil, fmt.Errorf("%w: %q", ErrInvalidDomainPattern, pattern)
+	}
+	return
+}
+
+// ParseHost is like the package-level ParseHost except that the rules of this
+// DomainParser are used
+func (p *DomainParser) ParseHost(input string) (host Host, err error) {
+	text := strings.TrimSpace(input)
+	if strings.Contains(text, "://") {
+		var u *url.URL
+		if u, err = url.Parse(text); err != nil {
+			return Host{}, fmt.Errorf("%w: %q", ErrInvalidHost, input)
+		}
+		text = u.Host
+	}
+
+	var name string
+	var hasPort bool
+	if rest, ok := strings.CutPrefix(text, "["); ok {
+		var after string
+		if name, after, ok = strings.Cut(rest, "]"); !ok {
+			return Host{}, fmt.Errorf("%w: %q", ErrInvalidHost, input)
+		} else if after != "" {
+			if host.Port, hasPort = strings.CutPrefix(after, ":"); !hasPort {
+				return Host{}, fmt.Errorf("%w: %q", ErrInvalidHost, input)
+			}
+		}
+	} else if strings.Count(text, ":") == 1 {
+		name, host.Port, hasPort = strings.Cut(text, ":")
+	} else {
+		// includes bare IPv6 addresses
+		name = text
+	}
+
+	if hasPort {
+		if port, ee := strconv.ParseUint(host.Port, 10, 16); ee != nil || port == 0 {
+			return Host{}, fmt.Errorf("%w: %q", ErrInvalidHost, input)
+		}
+	}
+
+	if name = strings.TrimSuffix(name, "."); name == "" {
+		return Host{}, fmt.Errorf("%w: %q", ErrInvalidHost, input)
+	} else if ip, ee := netip.ParseAddr(name); ee == nil {
+		host.IP = ip.Unmap()
+		host.Name = host.IP.String()
+		return
+	} else if strings.Contains(text, "[") {
+		// only IPv6 literals are bracketed
+		return Host{}, fmt.Errorf("%w: %q", ErrInvalidHost, input)
+	}
+
+	if host.Domain, err = p.ParseDomain(name); err != nil && !errors.Is(err, ErrMixedScriptDomain) {
+		return Host{}, fmt.Errorf("%w: %q", ErrInvalidHost, input)
+	}
+	host.Name = host.Domain.ASCII.Host
+	return
+}
+
+// RegistrableDomain is like the package-level RegistrableDomain except that
+// the rules of this DomainParser are used
+func (p *DomainParser) RegistrableDomain(input string) (domain string, err error) {
+	var host Host
+	if host, err = p.ParseHost(input); err != nil && !errors.Is(err, ErrMixedScriptDomain) {
+		return "", err
+	} else if domain = host.Registrable(); domain == "" {
+		return "", fmt.Errorf("%w: %q", ErrNoRegistrableDomain, input)
+	}
+	return
+}
+
+// SameSite is like the package-level SameSite except that the rules of this
+// DomainParser are used
+func (p *DomainParser) SameSite(a, b string) (same bool) {
+	first, ok := p.parseSiteHost(a)
+	if !ok {
+		return false
+	}
+	second, ok := p.parseSiteHost(b)
+	if !ok {
+		return false
+	} else if first.IsIP() || second.IsIP() {
+		return first.IP == second.IP
+	} else if site := first.Registrable(); site != "" {
+		return site == second.Registrable()
+	}
+	return second.Registrable() == "" && first.Name == second.Name
+}
+
+// IsSubdomainOf is like the package-level IsSubdomainOf except that the rules
+// of this DomainParser are used
+func (p *DomainParser) IsSubdomainOf(input, parent string) (subdomain bool) {
+	if host, ok := p.parseSiteHost(input); ok && !host.IsIP() {
+		if other, ok := p.parseSiteHost(parent); ok && !other.IsIP() {
+			return strings.HasSuffix(host.Name, "."+other.Name)
+		}
+	}
+	return false
+}
+
+// parseSiteHost is a convenience wrapper around ParseHost which accepts hosts
+// with mixed script labels
+func (p *DomainParser) parseSiteHost(input string) (host Host, ok bool) {
+	host, err := p.ParseHost(input)
+	return host, err == nil || errors.Is(err, ErrMixedScriptDomain)
+}
+
+// ParseHost parses the host of the `input` given, which is either a host
+// name, an IPv4 address or an IPv6 address, with an optional port number,
+// like "example.com:8080" or "[::1]:8080". Bare IPv6 addresses, like "::1",
+// trailing periods and URLs, like "https://example.com/path", are also
+// supported
+//
+// ParseHost returns an error wrapping ErrInvalidHost when the `input` is not
+// a valid host. Host names with mixed script labels are returned along with
+// an error wrapping ErrMixedScriptDomain, see ParseDomain
+//
+// ParseHost is a wrapper around DomainParser.ParseHost using the
+// DefaultDomainParser
+func ParseHost(input string) (host Host, err error) {
+	return DefaultDomainParser().ParseHost(input)
+}
+
+// RegistrableDomain returns the registrable domain of the host given, in
+// ASCII form, like "example.co.uk" for "www.example.co.uk:8080", see
+// Host.Registrable. RegistrableDomain returns an error wrapping
+// ErrNoRegistrableDomain for IP literals and hosts which are themselves public
+// suffixes, like "co.uk"
+//
+// RegistrableDomain is a wrapper around DomainParser.RegistrableDomain using
+// the DefaultDomainParser
+func RegistrableDomain(host string) (domain string, err error) {
+	return DefaultDomainParser().RegistrableDomain(host)
+}
+
+// SameSite returns true if the hosts given have the same registrable domain,
+// ignoring any ports, like "www.example.co.uk" and "api.example.co.uk:8080".
+// IP literals are only the same site as the same IP address and hosts which
+// have no registrable domain are only the same site as the same host
+//
+// SameSite is a wrapper around DomainParser.SameSite using the
+// DefaultDomainParser
+func SameSite(a, b string) (same bool) {
+	return DefaultDomainParser().SameSite(a, b)
+}
+
+// ParseDomainPattern parses a host name pattern, which is either an exact
+// host, like "example.com", a single label wildcard, like "*.example.com", or
+// a multiple label wildcard, like "**.example.com". Single label wildcards
+// match "www.example.com" but not "a.b.example.com", multiple label wildcards
+// match both and neither match "example.com" itself. Patterns may have a port
+// number, like "*.example.com:8443", and otherwise match any port
+//
+// ParseDomainPattern returns an error wrapping ErrInvalidDomainPattern when
+// the `pattern` is not valid, including wildcards of IP literals and of the
+// public suffixes, like "*.co.uk" or "**.github.io", which would match hosts
+// of unrelated sites
+//
+// ParseDomainPattern is a wrapper around DomainParser.ParseDomainPattern using
+// the DefaultDomainParser
+func ParseDomainPattern(pattern string) (d *DomainPattern, err error) {
+	return DefaultDomainParser().ParseDomainPattern(pattern)
+}
+
+// MatchDomainPattern is a convenience wrapper around ParseDomainPattern and
+// DomainPattern.Match, returning false when the `pattern` is not valid
+func MatchDomainPattern(pattern, host string) (matched bool) {
+	if d, err := ParseDomainPattern(pattern); err == nil {
+		return d.Match(host)
+	}
+	return false
+}
+
+// IsSubdomainOf returns true if the `host` is a subdomain of the `parent`,
+// ignoring any ports, like "www.example.com" and "example.com". A host is not
+// a subdomain of itself and IP literals are never subdomains
+//
+// IsSubdomainOf is a wrapper around DomainParser.IsSubdomainOf using the
+// DefaultDomainParser
+func IsSubdomainOf(host, parent string) (subdomain bool) {
+	return DefaultDomainParser().IsSubdomainOf(host, parent)
+}
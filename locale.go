@@ -0,0 +1,65 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// ToLowersIn is like ToLowers except that the case mapping rules of the
+// language given are used, for example with language.Turkish, "İstanbul" is
+// lower-cased to "istanbul" instead of "i̇stanbul" and with language.Greek a
+// trailing sigma is lower-cased to the final form "ς"
+func ToLowersIn(lang language.Tag, in ...string) (out []string) {
+	caser := cases.Lower(lang)
+	for _, i := range in {
+		out = append(out, caser.String(i))
+	}
+	return
+}
+
+// ToKebabsIn is like ToKebabs except that the Words are lower-cased using the
+// case mapping rules of the language given
+func ToKebabsIn(lang language.Tag, inputs ...string) (out []string) {
+	caser := cases.Lower(lang)
+	for _, i := range inputs {
+		out = append(out, joinWords(Words(i), "-", caser.String))
+	}
+	return
+}
+
+// ToTitleWordsIn is like ToTitleWords except that the first letter of each
+// word is title-cased using the case mapping rules of the language given, for
+// example with language.Turkish, "istanbul" is title-cased to "İstanbul"
+// instead of "Istanbul"
+//
+// ToTitleWordsIn is a wrapper around CaseConverter.ToTitleWordsIn using the
+// acronyms registered with RegisterAcronyms
+func ToTitleWordsIn(lang language.Tag, text string) (capitalized string) {
+	return gCaseConverter.ToTitleWordsIn(lang, text)
+}
+
+// ToTitleWordsIn is the language-aware version of CaseConverter.ToTitleWords
+func (c *CaseConverter) ToTitleWordsIn(lang language.Tag, text string) (capitalized string) {
+	caser := cases.Title(lang, cases.NoLower)
+	capitalized = replaceSpans(text, wordSpans(text), func(_ int, word string) (replaced string) {
+		if acronym, ok := c.Acronym(word); ok {
+			return acronym
+		}
+		return caser.String(word)
+	})
+	return
+}
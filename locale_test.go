@@ -0,0 +1,47 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/text/language"
+)
+
+func TestLocale(t *testing.T) {
+	Convey("ToLowersIn", t, func() {
+		So(ToLowersIn(language.English), ShouldBeNil)
+		So(ToLowersIn(language.English, "İstanbul"), ShouldEqual, []string{"i̇stanbul"})
+		So(ToLowersIn(language.Turkish, "İstanbul", "DİYARBAKIR"), ShouldEqual, []string{"istanbul", "diyarbakır"})
+		So(ToLowersIn(language.German, "STRAẞE"), ShouldEqual, []string{"straße"})
+		So(ToLowersIn(language.Greek, "ΟΔΟΣ ΟΔΟΣ"), ShouldEqual, []string{"οδος οδος"})
+	})
+
+	Convey("ToKebabsIn", t, func() {
+		So(ToKebabsIn(language.Turkish, "İstanbulIzmir"), ShouldEqual, []string{"istanbul-ızmir"})
+		So(ToKebabsIn(language.Greek, "ΟΔΟΣ_ΟΔΟΣ"), ShouldEqual, []string{"οδος-οδος"})
+	})
+
+	Convey("ToTitleWordsIn", t, func() {
+		So(ToTitleWordsIn(language.English, ""), ShouldEqual, "")
+		So(ToTitleWordsIn(language.English, "istanbul"), ShouldEqual, "Istanbul")
+		So(ToTitleWordsIn(language.Turkish, "istanbul ırmak"), ShouldEqual, "İstanbul Irmak")
+		So(ToTitleWordsIn(language.Dutch, "ijssel meer"), ShouldEqual, "IJssel Meer")
+		So(ToTitleWordsIn(language.German, "die straße"), ShouldEqual, "Die Straße")
+		So(ToTitleWordsIn(language.English, "don't the user id"), ShouldEqual, "Don't The User ID")
+		So(ToTitleWordsIn(language.English, "keep iPhone"), ShouldEqual, "Keep IPhone")
+	})
+}
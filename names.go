@@ -15,61 +15,397 @@
 package strings
 
 import (
+	"errors"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
-	fullname "github.com/amonsat/fullname_parser"
-	"github.com/iancoleman/strcase"
-	"github.com/weppos/publicsuffix-go/publicsuffix"
+	"golang.org/x/text/unicode/norm"
 )
 
-// FirstName is a wrapper around fullname_parser.ParseFullName
-func FirstName(fullName string) (firstName string) {
-	// TODO: fork fullname_parser, seems unmaintained
-	parsed := fullname.ParseFullname(fullName)
-	firstName = parsed.First
+const (
+	// NameFullPattern is the Name.Format pattern for the complete name, like
+	// "Dr. John Ronald Smith, Jr."
+	NameFullPattern = "{T} {F} {M} {L}, {S}"
+	// NameInitialedPattern is the Name.Format pattern for the First and Middle
+	// initials with the Last name, like "J. R. Smith"
+	NameInitialedPattern = "{F.} {M.} {L}"
+	// NameSortPattern is the Name.Format pattern for the Last name first, like
+	// "Smith, John R."
+	NameSortPattern = "{L}, {F} {M.}"
+	// NameInitialsPattern is the Name.Format pattern for just the initials,
+	// like "JRS"
+	NameInitialsPattern = "{I}"
+	// NameSalutationPattern is the Name.Format pattern for addressing someone
+	// formally, like "Dr. Smith"
+	NameSalutationPattern = "{T} {L}"
+)
+
+// nameApostropheMask is used by cutNickname to hide apostrophes from the
+// quote scanning
+const nameApostropheMask = '\x1a'
+
+var (
+	gNameTitles = newWordSet(
+		"mr mrs ms miss mx master dr doctor prof professor sir dame lord lady",
+		"rev reverend fr father sr sister br brother rabbi imam pastor hon",
+		"capt captain col colonel gen general lt maj major sgt cmdr adm judge",
+		"herr frau monsieur madame mme mlle señor señora sra srta",
+	)
+	gNameSuffixes = newWordSet(
+		"jr jnr sr snr ii iii iv esq esquire",
+		"phd md do dds dvm jd llm mba cpa rn obe mbe kbe",
+	)
+	gNameParticles = newWordSet(
+		"van von der den de del della des di da das do dos du la le lo",
+		"ter ten bin ibn bint al el af av zu st",
+	)
+)
+
+// Name is the structured form of a person's full name, see ParseName
+type Name struct {
+	// Title is any leading honorifics, like "Dr." or "Rev. Dr."
+	Title string
+	// First is the given name
+	First string
+	// Middle is all the names between the First and Last names
+	Middle string
+	// Last is the family name, including any particles like "van der"
+	Last string
+	// Suffix is any trailing generational or professional suffixes, like
+	// "Jr." or "PhD", separated by a comma and a space
+	Suffix string
+	// Nickname is the quoted or parenthesized nickname, like "Bob"
+	Nickname string
+}
+
+// ParseName parses the full name given into its component parts. Supported
+// forms include:
+//
+//	Dr. John "Johnny" Quincy Smith Jr.
+//	Smith, John Quincy, Jr.
+//	Ludwig van Beethoven
+//	Jean de la Fontaine
+//	Patrick (Pat) O'Brien
+//
+// Nicknames are the first quoted text found with ScanQuote, or the first
+// parenthesized text. Any leading titles and trailing suffixes are removed
+// before the remaining names are split into First, Middle and Last, where the
+// Last name includes any preceding particles, like "van der" or "de la". When
+// the full name starts with the Last name followed by a comma, the names
+// after the comma are the Title, First and Middle names and any further comma
+// separated parts are suffixes. A single name is always the First name
+func ParseName(full string) (name Name) {
+	full, name.Nickname = cutNickname(full)
+
+	parts := strings.Split(full, ",")
+	for idx := range parts {
+		parts[idx] = strings.TrimSpace(parts[idx])
+	}
+	var suffixes []string
+	for len(parts) > 1 && isNameSuffix(parts[len(parts)-1]) {
+		suffixes = append([]string{parts[len(parts)-1]}, suffixes...)
+		parts = parts[:len(parts)-1]
+	}
+
+	var tokens []string
+	if len(parts) > 1 {
+		// inverted form: Last, Title First Middle, Suffix...
+		tokens = strings.Fields(parts[1])
+		name.Last = strings.Join(strings.Fields(parts[0]), " ")
+		for _, extra := range parts[2:] {
+			if extra != "" {
+				suffixes = append(suffixes, extra)
+			}
+		}
+	} else {
+		tokens = strings.Fields(parts[0])
+	}
+
+	// titles
+	var titles []string
+	for len(tokens) > 1 && isNameTitle(tokens[0]) {
+		titles, tokens = append(titles, tokens[0]), tokens[1:]
+	}
+	name.Title = strings.Join(titles, " ")
+
+	// suffixes
+	var trailing []string
+	for len(tokens) > 1 && isNameSuffix(tokens[len(tokens)-1]) {
+		trailing = append([]string{tokens[len(tokens)-1]}, trailing...)
+		tokens = tokens[:len(tokens)-1]
+	}
+	name.Suffix = strings.Join(append(trailing, suffixes...), ", ")
+
+	if len(tokens) == 0 {
+		return
+	} else if name.First = tokens[0]; name.Last != "" {
+		name.Middle = strings.Join(tokens[1:], " ")
+		return
+	} else if len(tokens) == 1 {
+		return
+	}
+
+	// last name with any particles, never including the first name
+	start := len(tokens) - 1
+	for start > 1 && isNameParticle(tokens[start-1]) {
+		start -= 1
+	}
+	name.Middle = strings.Join(tokens[1:start], " ")
+	name.Last = strings.Join(tokens[start:], " ")
 	return
 }
 
-// LastName is a wrapper around fullname_parser.ParseFullName
-func LastName(fullName string) (lastName string) {
-	parsed := fullname.ParseFullname(fullName)
-	lastName = parsed.Last
+// FormatName parses the full name given with ParseName and renders it using
+// Name.Format with the `pattern` given
+func FormatName(fullName, pattern string) (formatted string) {
+	formatted = ParseName(fullName).Format(pattern)
 	return
 }
 
-// ParseDomainName returns the given name split into is component
-// parts, in reverse order
-func ParseDomainName(input string) (tld, name string, subdomains []string) {
-	if parsed, err := publicsuffix.Parse(input); err == nil {
-		tld, name = parsed.TLD, parsed.SLD
-		if parsed.TRD != "" {
-			subdomains = SplitSortReversed(parsed.TRD, ".")
+// Format renders the Name using the `pattern` given, where the following
+// placeholders are replaced with the Name parts:
+//
+//	{T}  Title
+//	{F}  First
+//	{M}  Middle
+//	{L}  Last
+//	{S}  Suffix
+//	{N}  Nickname
+//	{I}  upper case initials of the First, Middle and Last names, like "JRS"
+//
+// Adding a period to the First, Middle or Last placeholders renders the
+// initials of the name with periods instead, for example "{F.} {M.} {L}"
+// renders "J. R. Smith". Hyphenated names have initials for each part, like
+// "J.-L." for "Jean-Luc", and the initials of a Last name skip any particles,
+// so that "van der Berg" has the initial "B."
+//
+// Use "{{" and "}}" for literal braces, unknown placeholders are rendered
+// as-is. White space is collapsed when parts of the Name are empty and any
+// leading or trailing spaces and commas are removed, so that "{L}, {F}"
+// renders "Smith" when there is no First name
+func (n Name) Format(pattern string) (formatted string) {
+	var buf strings.Builder
+	for remainder := pattern; remainder != ""; {
+		before, after, found := strings.Cut(remainder, "{")
+		buf.WriteString(strings.ReplaceAll(before, "}}", "}"))
+		if !found {
+			break
+		} else if strings.HasPrefix(after, "{") {
+			buf.WriteString("{")
+			remainder = after[1:]
+			continue
 		}
-		return
+		key, rest, closed := strings.Cut(after, "}")
+		if value, ok := n.placeholder(key); closed && ok {
+			buf.WriteString(value)
+		} else if closed {
+			buf.WriteString("{" + key + "}")
+		} else {
+			buf.WriteString("{" + after)
+			break
+		}
+		remainder = rest
 	}
-	// fallback to always return something
-	list := SplitSortReversed(input, ".")
-	if count := len(list); count > 0 {
-		if tld = list[0]; count > 1 {
-			if name = list[1]; count > 2 {
-				subdomains = list[2:]
+	formatted = strings.Trim(strings.Join(strings.Fields(buf.String()), " "), " ,")
+	formatted = strings.ReplaceAll(formatted, " ,", ",")
+	return
+}
+
+// String returns the Name rendered with the NameFullPattern
+func (n Name) String() string {
+	return n.Format(NameFullPattern)
+}
+
+// placeholder returns the Format value for the placeholder `key` given
+func (n Name) placeholder(key string) (value string, ok bool) {
+	ok = true
+	switch key {
+	case "T":
+		value = n.Title
+	case "F":
+		value = n.First
+	case "F.":
+		value = nameInitials(n.First, true)
+	case "M":
+		value = n.Middle
+	case "M.":
+		value = nameInitials(n.Middle, true)
+	case "L":
+		value = n.Last
+	case "L.":
+		value = nameInitials(n.Last, true)
+	case "S":
+		value = n.Suffix
+	case "N":
+		value = n.Nickname
+	case "I":
+		value = nameInitials(n.First, false) + nameInitials(n.Middle, false) + nameInitials(n.Last, false)
+	default:
+		ok = false
+	}
+	return
+}
+
+// nameInitials returns the initials of the words in the `name` given, with
+// periods after each initial when `dots` is true. Particles are skipped
+// unless all the words are particles
+func nameInitials(name string, dots bool) (initials string) {
+	words := strings.Fields(name)
+	var major []string
+	for _, word := range words {
+		if !isNameParticle(word) {
+			major = append(major, word)
+		}
+	}
+	if len(major) > 0 {
+		words = major
+	}
+
+	var list []string
+	for _, word := range words {
+		var parts []string
+		for _, part := range strings.Split(word, "-") {
+			if idx := strings.IndexFunc(part, unicode.IsLetter); idx > -1 {
+				r, _ := utf8.DecodeRuneInString(part[idx:])
+				if initial := string(unicode.ToUpper(r)); dots {
+					parts = append(parts, initial+".")
+				} else {
+					parts = append(parts, initial)
+				}
 			}
 		}
+		if dots {
+			list = append(list, strings.Join(parts, "-"))
+		} else {
+			list = append(list, strings.Join(parts, ""))
+		}
+	}
+	if dots {
+		return strings.Join(list, " ")
 	}
+	return strings.Join(list, "")
+}
+
+// FirstName returns the First name of the full name given, see ParseName
+func FirstName(fullName string) (firstName string) {
+	firstName = ParseName(fullName).First
+	return
+}
+
+// LastName returns the Last name of the full name given, see ParseName
+func LastName(fullName string) (lastName string) {
+	lastName = ParseName(fullName).Last
+	return
+}
+
+// cutNickname removes the first quoted or parenthesized nickname from the
+// `full` name given. Apostrophes within words, like "O'Brien", are not quotes
+func cutNickname(full string) (modified, nickname string) {
+	// mask apostrophes within words from the quote scanning
+	masked := []byte(full)
+	for idx, r := range full {
+		if r == '\'' && idx > 0 {
+			prev, _ := utf8.DecodeLastRuneInString(full[:idx])
+			next, _ := utf8.DecodeRuneInString(full[idx+1:])
+			if unicode.IsLetter(prev) && unicode.IsLetter(next) {
+				masked[idx] = nameApostropheMask
+			}
+		}
+	}
+
+	if before, quoted, after, found := ScanQuote(string(masked)); found {
+		// the before and after lengths are the same within `full`
+		nickname = strings.TrimSpace(strings.ReplaceAll(quoted, string(nameApostropheMask), "'"))
+		modified = full[:len(before)] + " " + full[len(full)-len(after):]
+		return
+	} else if before, middle, after, found := Carve(full, "(", ")"); found {
+		return before + " " + after, strings.TrimSpace(middle)
+	}
+	return full, ""
+}
+
+// isNameTitle returns true if the `token` is one of the known titles
+func isNameTitle(token string) (ok bool) {
+	_, ok = gNameTitles[nameKey(token)]
 	return
 }
 
+// isNameSuffix returns true if the `token` is one of the known suffixes
+func isNameSuffix(token string) (ok bool) {
+	_, ok = gNameSuffixes[nameKey(token)]
+	return
+}
+
+// isNameParticle returns true if the `token` is one of the known surname
+// particles
+func isNameParticle(token string) (ok bool) {
+	_, ok = gNameParticles[nameKey(token)]
+	return
+}
+
+// nameKey returns the `token` lower-cased without any periods or commas
+func nameKey(token string) (key string) {
+	return strings.ToLower(strings.NewReplacer(".", "", ",", "").Replace(token))
+}
+
+// ParseDomainName returns the given name split into is component
+// parts, in reverse order. Internationalized domain names are returned in
+// their Unicode form, so that "xn--bcher-kva.example.de" and
+// "bücher.example.de" both produce the same results, see ParseDomain for
+// the ASCII forms
+//
+// ParseDomainName is a wrapper around DomainParser.ParseDomainName using the
+// DefaultDomainParser
+func ParseDomainName(input string) (tld, name string, subdomains []string) {
+	return DefaultDomainParser().ParseDomainName(input)
+}
+
 // NameFromEmail returns a user's default name based on just their
 // email address, intended to be used as an interesting placeholder
 // on a text input field for the user to supply something better
+//
+// When the email address has a display name, like `"Jane Doe" <jane@x.com>`,
+// the display name is returned as-is, otherwise the name is made from the
+// local part, without any plus-addressing tag, and the domain name. Local
+// parts which GuessNameFromEmail is reasonably confident about are used as
+// guessed, so that "jane.doe@acme.com" becomes "Jane Doe @Acme", while single
+// word local parts are always used as-is, like "Jsmith @Acme"
+//
+// NameFromEmail is a wrapper around CaseConverter.NameFromEmail using the
+// acronyms registered with RegisterAcronyms, which are only applied to the
+// domain name and never to the person's name, so that "ram@ram.com" becomes
+// "Ram @RAM"
 func NameFromEmail(email string) (name string) {
+	return gCaseConverter.NameFromEmail(email)
+}
+
+// NameFromEmail is the acronym-aware version of the package-level
+// NameFromEmail function
+func (c *CaseConverter) NameFromEmail(email string) (name string) {
+	if parsed, err := ParseEmail(email); err == nil || errors.Is(err, ErrMixedScriptDomain) {
+		if parsed.Display != "" {
+			return parsed.Display
+		}
+		domain := " @" + joinWords(c.Words(parsed.Domain.Unicode.Name), "", c.Capitalize)
+		if guess, confidence := GuessNameFromEmail(email); confidence >= 0.5 {
+			return guess.String() + domain
+		}
+		return nameFromLocal(parsed.User) + domain
+	}
 	// split the interesting parts
-	before, after, _ := strings.Cut(email, "@")
+	before, after, _ := strings.Cut(norm.NFC.String(email), "@")
 	// make the name and check the after
-	if name = ToSpacedCamel(before); after != "" {
+	if name = nameFromLocal(before); after != "" {
 		// suffix the name with a parsed domain
 		_, domain, _ := ParseDomainName(after)
-		name += " @" + strcase.ToCamel(domain)
+		name += " @" + joinWords(c.Words(domain), "", c.Capitalize)
 	}
 	return
 }
+
+// nameFromLocal capitalizes the words of the email `local` part given, without
+// any acronyms
+func nameFromLocal(local string) (name string) {
+	return joinWords(NewSegmenter().Words(local), " ", capitalize)
+}
@@ -24,6 +24,78 @@ func TestNames(t *testing.T) {
 	Convey("FirstName, LastName", t, func() {
 		So(FirstName("First M Last"), ShouldEqual, "First")
 		So(LastName("First Middle Last"), ShouldEqual, "Last")
+		So(FirstName("Smith, John"), ShouldEqual, "John")
+		So(LastName("Ludwig van Beethoven"), ShouldEqual, "van Beethoven")
+	})
+
+	Convey("ParseName", t, func() {
+		checks := []struct {
+			input string
+			name  Name
+		}{
+			{"", Name{}},
+			{"Cher", Name{First: "Cher"}},
+			{"  John   Smith ", Name{First: "John", Last: "Smith"}},
+			{"John Quincy Adams", Name{First: "John", Middle: "Quincy", Last: "Adams"}},
+			{"Dr. John Smith", Name{Title: "Dr.", First: "John", Last: "Smith"}},
+			{"Rev. Dr. Martin Luther King Jr.", Name{Title: "Rev. Dr.", First: "Martin", Middle: "Luther", Last: "King", Suffix: "Jr."}},
+			{"John Smith III PhD", Name{First: "John", Last: "Smith", Suffix: "III, PhD"}},
+			{"John Smith, Jr.", Name{First: "John", Last: "Smith", Suffix: "Jr."}},
+			{"John Smith, Jr., Ph.D.", Name{First: "John", Last: "Smith", Suffix: "Jr., Ph.D."}},
+			{"Smith, John", Name{First: "John", Last: "Smith"}},
+			{"Smith, Dr. John Quincy, Jr.", Name{Title: "Dr.", First: "John", Middle: "Quincy", Last: "Smith", Suffix: "Jr."}},
+			{"van der Berg, Jan", Name{First: "Jan", Last: "van der Berg"}},
+			{"Jan van der Berg", Name{First: "Jan", Last: "van der Berg"}},
+			{"Jean de la Fontaine", Name{First: "Jean", Last: "de la Fontaine"}},
+			{"Ludwig van Beethoven", Name{First: "Ludwig", Last: "van Beethoven"}},
+			{"Van Morrison", Name{First: "Van", Last: "Morrison"}},
+			{`John "Johnny" Smith`, Name{First: "John", Last: "Smith", Nickname: "Johnny"}},
+			{`William 'Bill' O'Brien`, Name{First: "William", Last: "O'Brien", Nickname: "Bill"}},
+			{`Patrick O'Brien 'Pat'`, Name{First: "Patrick", Last: "O'Brien", Nickname: "Pat"}},
+			{"Robert (Bob) Jones", Name{First: "Robert", Last: "Jones", Nickname: "Bob"}},
+			{"Mr. Smith", Name{Title: "Mr.", First: "Smith"}},
+			{"Dr", Name{First: "Dr"}},
+			{"José María García Márquez", Name{First: "José", Middle: "María García", Last: "Márquez"}},
+		}
+		for _, check := range checks {
+			So(ParseName(check.input), ShouldResemble, check.name)
+		}
+	})
+
+	Convey("FormatName", t, func() {
+		checks := []struct {
+			input   string
+			pattern string
+			output  string
+		}{
+			{"", NameFullPattern, ""},
+			{"John Ronald Smith", "{F.} {M.} {L}", "J. R. Smith"},
+			{"John Ronald Smith", "{L}, {F} {M.}", "Smith, John R."},
+			{"John Ronald Reuel Tolkien", NameInitialsPattern, "JRRT"},
+			{"John Ronald Reuel Tolkien", NameInitialedPattern, "J. R. R. Tolkien"},
+			{"Dr. John Smith", NameSalutationPattern, "Dr. Smith"},
+			{"John Smith", NameSalutationPattern, "Smith"},
+			{"John Smith", NameSortPattern, "Smith, John"},
+			{"Smith", "{L}, {F}", "Smith"},
+			{"Dr. John Ronald Smith Jr.", NameFullPattern, "Dr. John Ronald Smith, Jr."},
+			{"John Smith", NameFullPattern, "John Smith"},
+			{"Jean-Luc Picard", "{F.} {L}", "J.-L. Picard"},
+			{"Jean-Luc Picard", "{I}", "JLP"},
+			{"Mary Smith-Jones", "{F} {L.}", "Mary S.-J."},
+			{"Mary Smith-Jones", "{I}", "MSJ"},
+			{"Jan van der Berg", "{F.} {L}", "J. van der Berg"},
+			{"Jan van der Berg", "{L.}", "B."},
+			{"Jan van der Berg", "{I}", "JB"},
+			{"Dr. Jan van der Berg", NameSalutationPattern, "Dr. van der Berg"},
+			{"william o'brien", "{I}", "WO"},
+			{`John "Johnny" Smith`, "{N} {L}", "Johnny Smith"},
+			{"John Smith", "{{F}} {X} {F", "{F} {X} {F"},
+		}
+		for _, check := range checks {
+			So(FormatName(check.input, check.pattern), ShouldEqual, check.output)
+		}
+
+		So(ParseName("Dr. John Smith, Jr.").String(), ShouldEqual, "Dr. John Smith, Jr.")
 	})
 
 	Convey("ParseDomainName", t, func() {
@@ -36,9 +108,34 @@ func TestNames(t *testing.T) {
 		So(tld, ShouldEqual, "org")
 		So(name, ShouldEqual, "go-enjin")
 		So(subs, ShouldEqual, []string{""})
+
+		for _, input := range []string{"bücher.example.de", "xn--bcher-kva.example.de", "BU\u0308CHER.EXAMPLE.DE"} {
+			tld, name, subs = ParseDomainName(input)
+			So(tld, ShouldEqual, "de")
+			So(name, ShouldEqual, "example")
+			So(subs, ShouldEqual, []string{"bücher"})
+		}
 	})
 
 	Convey("NameFromEmail", t, func() {
 		So(NameFromEmail("name@addr.ess"), ShouldEqual, "Name @Addr")
+		So(NameFromEmail("ram@x.com"), ShouldEqual, "Ram @X")
+		So(NameFromEmail("id@id.com"), ShouldEqual, "Id @ID")
+		So(NameFromEmail("ram.id@x.com"), ShouldEqual, "Ram Id @X")
+		So(NameFromEmail("jürgen@münchen.de"), ShouldEqual, "Jürgen @München")
+		So(NameFromEmail("ju\u0308rgen@xn--mnchen-3ya.de"), ShouldEqual, "Jürgen @München")
+		So(NameFromEmail("first.last+news@example.co.uk"), ShouldEqual, "First Last @Example")
+		So(NameFromEmail(`"Jane Doe" <jane.doe@example.com>`), ShouldEqual, "Jane Doe")
+		So(NameFromEmail("Jane Doe <jane.doe@example.com>"), ShouldEqual, "Jane Doe")
+		So(NameFromEmail("name"), ShouldEqual, "Name")
+		So(NameFromEmail("jsmith@acme.com"), ShouldEqual, "Jsmith @Acme")
+		So(NameFromEmail("nguyen@x.com"), ShouldEqual, "Nguyen @X")
+		So(NameFromEmail("dmitri@x.com"), ShouldEqual, "Dmitri @X")
+		So(NameFromEmail("lloyd@x.com"), ShouldEqual, "Lloyd @X")
+		So(NameFromEmail("bjorn@x.com"), ShouldEqual, "Bjorn @X")
+		So(NameFromEmail("mcdonald@x.com"), ShouldEqual, "Mcdonald @X")
+		So(NameFromEmail("ngozi@x.com"), ShouldEqual, "Ngozi @X")
+		So(NameFromEmail("janeDoe42@acme.com"), ShouldEqual, "Jane Doe @Acme")
+		So(NameFromEmail("info@acme.com"), ShouldEqual, "Info @Acme")
 	})
 }
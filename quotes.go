@@ -18,12 +18,34 @@ import (
 	"strings"
 )
 
+// QuotedSpan describes a quoted section of text found by ScanQuoteAll
+type QuotedSpan struct {
+	// Quote is the starting quotation rune
+	Quote rune
+	// Raw is the text between the quotation runes, as-is
+	Raw string
+	// Value is the unquoted (and unescaped) Raw text
+	Value string
+	// Start is the byte offset of the starting quotation rune
+	Start int
+	// End is the byte offset immediately following the ending quotation rune
+	End int
+}
+
 type QuotePair struct {
 	Start rune
 	End   rune
 }
 
 var (
+	// BasicQuotes are the double-quote ("), single-quote (') and backtick (`)
+	// quotation pairs recognized by IsQuote
+	BasicQuotes = []QuotePair{
+		{Start: '"', End: '"'},
+		{Start: '\'', End: '\''},
+		{Start: '`', End: '`'},
+	}
+
 	FancyQuotes = []QuotePair{
 		{Start: '“', End: '”'},
 		{Start: '‘', End: '’'},
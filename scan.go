@@ -15,126 +15,86 @@
 package strings
 
 import (
-	"strconv"
+	"bufio"
 )
 
 // Scan is a text scanner which looks for unquoted and unescaped `sep`
+//
+// Scan is a wrapper around Scanner.Scan using the NewScanner defaults
 func Scan(src, sep string) (before, after string, found bool) {
-
-	s := struct {
-		quote  rune
-		quoted bool
-	}{}
-
-	runes := []rune(src)
-	total, size := len(runes), len(sep)
-
-	for idx := 0; idx < total; idx++ {
-
-		r := runes[idx]
-
-		if r == '\\' {
-			// next character is escaped, skip
-			idx += 1
-			continue
-		} else if IsQuote(r) {
-			// this character is a double, single or backtick quotation detected
-			if s.quoted {
-				// scanning within a quoted string
-				if s.quote == r {
-					// this character is the ending quotation
-					s.quote = 0
-					s.quoted = false
-				}
-				// nothing to do with quoted contents
-				continue
-			}
-			// this character is a starting quotation
-			s.quote = r
-			s.quoted = true
-			continue
-		} else if s.quoted {
-			// nothing to do with quoted contents
-			continue
-		} else if remainder := total - idx; size > remainder {
-			// early out, not enough characters for sep matching
-			break
-		} else if found = src[idx:idx+size] == sep; found {
-			// sep match found, Scan complete
-			before = src[:idx]
-			after = src[idx+size:]
-			return
-		}
-
-	}
-
-	return src, "", false
+	return gDefaultScanner.Scan(src, sep)
 }
 
 // ScanQuote looks for the first single, double or backtick quoted text,
 // returning the `before`, `quoted` and `after` strings if `found`. Note that
 // the `quoted` string is unquoted (and unescaped). Use strconv.Quote to
 // restore double-quoting
+//
+// ScanQuote is a wrapper around Scanner.ScanQuote using the NewScanner
+// defaults
 func ScanQuote(src string) (before, quoted, after string, found bool) {
+	return gDefaultScanner.ScanQuote(src)
+}
 
-	s := struct {
-		quote  rune
-		quoted bool
-	}{}
-
-	runes := []rune(src)
-	total := len(runes)
+// ScanQuoteAll returns all the single, double and backtick quoted text found
+// in `src`, in the order they appear
+//
+// ScanQuoteAll is a wrapper around Scanner.ScanQuoteAll using the NewScanner
+// defaults
+func ScanQuoteAll(src string) (spans []QuotedSpan) {
+	return gDefaultScanner.ScanQuoteAll(src)
+}
 
-	for idx := 0; idx < total; idx++ {
+// ScanE is like Scan except that when `sep` is not found, ScanE returns a
+// *ScanError if `src` has an unterminated quotation or a trailing backslash
+//
+// ScanE is a wrapper around Scanner.ScanE using the NewScanner defaults
+func ScanE(src, sep string) (before, after string, found bool, err error) {
+	return gDefaultScanner.ScanE(src, sep)
+}
 
-		r := runes[idx]
+// ScanQuoteE is like ScanQuote except that ScanQuoteE returns a *ScanError
+// when the first quotation is unterminated, when `src` has a trailing
+// backslash or when the quoted text has an invalid escape sequence
+//
+// ScanQuoteE is a wrapper around Scanner.ScanQuoteE using the NewScanner
+// defaults
+func ScanQuoteE(src string) (before, quoted, after string, found bool, err error) {
+	return gDefaultScanner.ScanQuoteE(src)
+}
 
-		if r == '\\' {
-			// next character is escaped, skip
-			if s.quoted {
-				quoted += string(r)
-				if idx+1 < total {
-					quoted += string(runes[idx+1])
-				}
-			}
-			idx += 1
-			continue
-		} else if IsQuote(r) {
-			// this character is a double, single or backtick quotation detected
-			if s.quoted {
-				// scanning within a quoted string
-				if found = s.quote == r; found {
-					// this character is the ending quotation
-					s.quoted = false
-					if idx < total-1 {
-						after = string(runes[idx+1:])
-					}
-					break
-				}
-				// nothing to do with quoted contents
-				quoted += string(r)
-				continue
-			}
-			// this character is a starting quotation
-			s.quote = r
-			s.quoted = true
-			continue
-		} else if s.quoted {
-			// keep quoted contents
-			quoted += string(r)
-			continue
-		} else {
-			before += string(r)
-		}
+// ScanSplit is like [strings.Split] except that only the unquoted and
+// unescaped instances of `sep` are split upon
+//
+// ScanSplit is a wrapper around Scanner.ScanSplit using the NewScanner
+// defaults
+func ScanSplit(src, sep string) (parts []string) {
+	return gDefaultScanner.ScanSplit(src, sep)
+}
 
-	}
+// ScanSplitN is like [strings.SplitN] except that only the unquoted and
+// unescaped instances of `sep` are split upon
+//
+// ScanSplitN is a wrapper around Scanner.ScanSplitN using the NewScanner
+// defaults
+func ScanSplitN(src, sep string, n int) (parts []string) {
+	return gDefaultScanner.ScanSplitN(src, sep, n)
+}
 
-	if found {
-		if unquoted, err := strconv.Unquote(string(s.quote) + quoted + string(s.quote)); err == nil {
-			quoted = unquoted
-		}
-		return
-	}
+// ScanSegments returns an iterator function which lazily yields each of the
+// segments ScanSplit would return
+//
+// ScanSegments is a wrapper around Scanner.ScanSegments using the NewScanner
+// defaults
+func ScanSegments(src, sep string) (segments func(yield func(segment string) bool)) {
+	return gDefaultScanner.ScanSegments(src, sep)
+}
 
-	return src, "", "", false
+// ScanSeparatorFunc returns a [bufio.SplitFunc] which splits streamed input
+// on unquoted and unescaped instances of `sep`
+//
+// ScanSeparatorFunc is a wrapper around Scanner.SplitFunc using the
+// NewScanner defaults, see Scanner.SplitFunc for details
+func ScanSeparatorFunc(sep string) (split bufio.SplitFunc) {
+	return NewScanner().SplitFunc(sep)
 }
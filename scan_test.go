@@ -15,8 +15,13 @@
 package strings
 
 import (
+	"bufio"
+	"errors"
+	"io"
 	"math/rand"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -132,6 +137,249 @@ func TestScan(t *testing.T) {
 		}
 	})
 
+	Convey("Scan utf-8", t, func() {
+		b, a, ok := Scan(`ünïcödé "¦" ¦ after`, "¦")
+		So(ok, ShouldBeTrue)
+		So(b, ShouldEqual, `ünïcödé "¦" `)
+		So(a, ShouldEqual, ` after`)
+
+		b, a, ok = Scan(`日本語\日本語,語`, ",")
+		So(ok, ShouldBeTrue)
+		So(b, ShouldEqual, `日本語\日本語`)
+		So(a, ShouldEqual, `語`)
+
+		b, a, ok = Scan(`日本語\,語`, ",")
+		So(ok, ShouldBeFalse)
+		So(b, ShouldEqual, `日本語\,語`)
+		So(a, ShouldEqual, ``)
+
+		b, a, ok = Scan("\xff\xfe,\x80", ",")
+		So(ok, ShouldBeTrue)
+		So(b, ShouldEqual, "\xff\xfe")
+		So(a, ShouldEqual, "\x80")
+
+		b, m, a, ok := ScanBothCarve(`“日本” {{ “語” }} 語`, "{{", "}}")
+		So(ok, ShouldBeTrue)
+		So(b, ShouldEqual, `“日本” `)
+		So(m, ShouldEqual, ` “語” `)
+		So(a, ShouldEqual, ` 語`)
+	})
+
+	Convey("ScanQuoteAll", t, func() {
+		So(ScanQuoteAll(`nothing quoted`), ShouldBeNil)
+
+		src := `{{ _ "Hello, \"World\"" }} and {{ _ 'ünï' }} or {{ _ ` + "`raw\\n`" + ` }} "unterminated`
+		spans := ScanQuoteAll(src)
+		So(spans, ShouldHaveLength, 3)
+
+		So(spans[0].Quote, ShouldEqual, '"')
+		So(spans[0].Raw, ShouldEqual, `Hello, \"World\"`)
+		So(spans[0].Value, ShouldEqual, `Hello, "World"`)
+		So(src[spans[0].Start:spans[0].End], ShouldEqual, `"Hello, \"World\""`)
+
+		So(spans[1].Quote, ShouldEqual, '\'')
+		So(spans[1].Raw, ShouldEqual, `ünï`)
+		So(spans[1].Value, ShouldEqual, `ünï`)
+		So(src[spans[1].Start:spans[1].End], ShouldEqual, `'ünï'`)
+
+		So(spans[2].Quote, ShouldEqual, '`')
+		So(spans[2].Raw, ShouldEqual, `raw\n`)
+		So(spans[2].Value, ShouldEqual, `raw\n`)
+		So(src[spans[2].Start:spans[2].End], ShouldEqual, "`raw\\n`")
+
+		spans = NewFancyScanner().ScanQuoteAll(`„one“ and «two»`)
+		So(spans, ShouldHaveLength, 2)
+		So(spans[0].Quote, ShouldEqual, '„')
+		So(spans[0].Value, ShouldEqual, `one`)
+		So(spans[0].Start, ShouldEqual, 0)
+		So(spans[0].End, ShouldEqual, 9)
+		So(spans[1].Quote, ShouldEqual, '«')
+		So(spans[1].Value, ShouldEqual, `two`)
+	})
+
+	Convey("ScanE", t, func() {
+		b, a, ok, err := ScanE(`one "two,three" , four`, ",")
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+		So(b, ShouldEqual, `one "two,three" `)
+		So(a, ShouldEqual, ` four`)
+
+		b, a, ok, err = ScanE(`one two`, ",")
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeFalse)
+		So(b, ShouldEqual, `one two`)
+		So(a, ShouldEqual, ``)
+
+		var se *ScanError
+		b, _, ok, err = ScanE("one\ntwo \"three, four", ",")
+		So(ok, ShouldBeFalse)
+		So(b, ShouldEqual, "one\ntwo \"three, four")
+		So(errors.As(err, &se), ShouldBeTrue)
+		So(se.Kind, ShouldEqual, ScanUnterminatedQuote)
+		So(se.Rune, ShouldEqual, '"')
+		So(se.Offset, ShouldEqual, 8)
+		So(se.Line, ShouldEqual, 2)
+		So(se.Column, ShouldEqual, 5)
+
+		_, _, ok, err = ScanE(`one two\`, ",")
+		So(ok, ShouldBeFalse)
+		So(errors.As(err, &se), ShouldBeTrue)
+		So(se.Kind, ShouldEqual, ScanTrailingEscape)
+		So(se.Rune, ShouldEqual, '\\')
+		So(se.Offset, ShouldEqual, 7)
+	})
+
+	Convey("ScanQuoteE", t, func() {
+		b, q, a, ok, err := ScanQuoteE(`before 'it\'s' after`)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+		So(b, ShouldEqual, `before `)
+		So(q, ShouldEqual, `it's`)
+		So(a, ShouldEqual, ` after`)
+
+		b, q, a, ok, err = ScanQuoteE(`nothing quoted`)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeFalse)
+		So(b, ShouldEqual, `nothing quoted`)
+
+		var se *ScanError
+		b, q, a, ok, err = ScanQuoteE(`before "quoted`)
+		So(ok, ShouldBeFalse)
+		So(b, ShouldEqual, `before "quoted`)
+		So(errors.As(err, &se), ShouldBeTrue)
+		So(se.Kind, ShouldEqual, ScanUnterminatedQuote)
+		So(se.Offset, ShouldEqual, 7)
+		So(se.Error(), ShouldEqual, "unterminated double quote starting at line 1, column 8")
+
+		b, q, a, ok, err = ScanQuoteE(`before "in\qvalid" after`)
+		So(ok, ShouldBeTrue)
+		So(b, ShouldEqual, `before `)
+		So(q, ShouldEqual, `in\qvalid`)
+		So(a, ShouldEqual, ` after`)
+		So(errors.As(err, &se), ShouldBeTrue)
+		So(se.Kind, ShouldEqual, ScanInvalidEscape)
+		So(se.Rune, ShouldEqual, '\\')
+		So(se.Quote, ShouldEqual, '"')
+		So(se.Offset, ShouldEqual, 10)
+
+		_, q, _, ok = ScanQuote(`before "in\qvalid" after`)
+		So(ok, ShouldBeTrue)
+		So(q, ShouldEqual, `in\qvalid`)
+	})
+
+	Convey("ScanSplit", t, func() {
+		So(ScanSplit(``, ","), ShouldEqual, []string{""})
+		So(ScanSplit(`one`, ","), ShouldEqual, []string{"one"})
+		So(ScanSplit(`a="x,y",b=2`, ","), ShouldEqual, []string{`a="x,y"`, `b=2`})
+		So(ScanSplit(`a\,b,'c,d',`, ","), ShouldEqual, []string{`a\,b`, `'c,d'`, ``})
+		So(ScanSplit(`a, b, "c, d"`, ", "), ShouldEqual, []string{`a`, `b`, `"c, d"`})
+		So(ScanSplit(`abc`, ""), ShouldEqual, []string{"a", "b", "c"})
+	})
+
+	Convey("ScanSplitN", t, func() {
+		So(ScanSplitN(`a,"b,c",d`, ",", 0), ShouldBeNil)
+		So(ScanSplitN(`a,"b,c",d`, ",", 1), ShouldEqual, []string{`a,"b,c",d`})
+		So(ScanSplitN(`a,"b,c",d`, ",", 2), ShouldEqual, []string{`a`, `"b,c",d`})
+		So(ScanSplitN(`a,"b,c",d`, ",", 3), ShouldEqual, []string{`a`, `"b,c"`, `d`})
+		So(ScanSplitN(`a,"b,c",d`, ",", 4), ShouldEqual, []string{`a`, `"b,c"`, `d`})
+		So(ScanSplitN(`a,"b,c",d`, ",", -1), ShouldEqual, []string{`a`, `"b,c"`, `d`})
+		So(ScanSplitN(`abc`, "", 2), ShouldEqual, []string{"a", "bc"})
+	})
+
+	Convey("ScanSegments", t, func() {
+		var segments []string
+		ScanSegments(`a,"b,c",d`, ",")(func(segment string) bool {
+			segments = append(segments, segment)
+			return true
+		})
+		So(segments, ShouldEqual, []string{`a`, `"b,c"`, `d`})
+
+		segments = nil
+		ScanSegments(`a,"b,c",d`, ",")(func(segment string) bool {
+			segments = append(segments, segment)
+			return len(segments) < 2
+		})
+		So(segments, ShouldEqual, []string{`a`, `"b,c"`})
+
+		segments = nil
+		ScanSegments(`ab`, "")(func(segment string) bool {
+			segments = append(segments, segment)
+			return true
+		})
+		So(segments, ShouldEqual, []string{`a`, `b`})
+	})
+
+	Convey("ScanSeparatorFunc", t, func() {
+		split := func(r io.Reader, sep string) (tokens []string, err error) {
+			s := bufio.NewScanner(r)
+			s.Buffer(make([]byte, 4), 64)
+			s.Split(ScanSeparatorFunc(sep))
+			for s.Scan() {
+				tokens = append(tokens, s.Text())
+			}
+			err = s.Err()
+			return
+		}
+
+		for _, sep := range []string{",", "}}", "¦¦"} {
+			src := strings.Join([]string{`a`, `"b` + sep + `c"`, `d\` + sep + `e`, `ünï“cödé”`, ``, `'f` + sep + `'`}, sep)
+			expected := ScanSplit(src, sep)
+			tokens, err := split(iotest.OneByteReader(strings.NewReader(src)), sep)
+			So(err, ShouldBeNil)
+			So(tokens, ShouldEqual, expected)
+			tokens, err = split(strings.NewReader(src), sep)
+			So(err, ShouldBeNil)
+			So(tokens, ShouldEqual, expected)
+		}
+
+		tokens, err := split(strings.NewReader(`one,two,`), ",")
+		So(err, ShouldBeNil)
+		So(tokens, ShouldEqual, []string{"one", "two"})
+
+		tokens, err = split(strings.NewReader(`one,"two`), ",")
+		So(err, ShouldBeNil)
+		So(tokens, ShouldEqual, []string{"one", `"two`})
+
+		tokens, err = split(strings.NewReader(`ab`), "")
+		So(err, ShouldBeNil)
+		So(tokens, ShouldEqual, []string{"a", "b"})
+
+		_, err = split(strings.NewReader(strings.Repeat(`"`+strings.Repeat(",", 64)+`"`, 2)), ",")
+		So(err, ShouldEqual, bufio.ErrTooLong)
+	})
+
+}
+
+func FuzzScan(f *testing.F) {
+	f.Add("one two more", " ")
+	f.Add(`"two {{more}}" \}} }} after`, "}}")
+	f.Add(`ünïcödé "“quoted”" ¦ after`, "¦")
+	f.Add("日本語\\日本語,語", ",")
+	f.Add("\xff\xfe,\x80", ",")
+	f.Fuzz(func(t *testing.T, src, sep string) {
+		if before, after, found := Scan(src, sep); found {
+			if before+sep+after != src {
+				t.Errorf("Scan(%q, %q) = %q, %q; before+sep+after != src", src, sep, before, after)
+			}
+		} else if before != src || after != "" {
+			t.Errorf("Scan(%q, %q) = %q, %q; not found should return src", src, sep, before, after)
+		}
+		if before, middle, after, found := ScanCarve(src, sep, sep); found {
+			if before+sep+middle+sep+after != src {
+				t.Errorf("ScanCarve(%q, %q) = %q, %q, %q; segments do not rebuild src", src, sep, before, middle, after)
+			}
+		}
+		if before, middle, after, found := ScanBothCarve(src, sep, sep); found {
+			if before+sep+middle+sep+after != src {
+				t.Errorf("ScanBothCarve(%q, %q) = %q, %q, %q; segments do not rebuild src", src, sep, before, middle, after)
+			}
+		}
+		if sep != "" {
+			if joined := strings.Join(ScanSplit(src, sep), sep); joined != src {
+				t.Errorf("ScanSplit(%q, %q) joined = %q; does not rebuild src", src, sep, joined)
+			}
+		}
+	})
 }
 
 func BenchmarkScan(b *testing.B) {
@@ -0,0 +1,489 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	gDefaultScanner = NewScanner()
+	gPlainScanner   = &Scanner{}
+)
+
+// Scanner is a configurable text scanner which looks for unquoted and
+// unescaped separators. The zero value Scanner has no quotes and no escape
+// rune, see NewScanner for the defaults used by Scan
+type Scanner struct {
+	// Quotes is the list of quotation pairs recognized, a Start rune can be
+	// paired with more than one End rune
+	Quotes []QuotePair
+	// Escape is the rune which escapes the rune following it, zero disables
+	// escaping entirely
+	Escape rune
+	// RawBackticks disables escaping within backtick quoted text, similar to
+	// Go raw string literals
+	RawBackticks bool
+}
+
+// NewScanner returns a new Scanner instance configured with the BasicQuotes
+// and the backslash escape rune, which is the configuration used by Scan,
+// ScanQuote and the other package-level Scan functions
+func NewScanner() (s *Scanner) {
+	quotes := make([]QuotePair, len(BasicQuotes))
+	copy(quotes, BasicQuotes)
+	s = &Scanner{
+		Quotes: quotes,
+		Escape: '\\',
+	}
+	return
+}
+
+// NewFancyScanner is like NewScanner except that the FancyQuotes are also
+// recognized
+func NewFancyScanner() (s *Scanner) {
+	s = NewScanner()
+	s.Quotes = append(s.Quotes, FancyQuotes...)
+	return
+}
+
+// IsStart returns true if the rune given is the Start of any of the Scanner
+// Quotes
+func (s *Scanner) IsStart(r rune) (ok bool) {
+	for _, p := range s.Quotes {
+		if ok = p.Start == r; ok {
+			return
+		}
+	}
+	return
+}
+
+// IsEnd returns true if the `end` rune given closes any of the Scanner Quotes
+// started with the `start` rune
+func (s *Scanner) IsEnd(start, end rune) (ok bool) {
+	for _, p := range s.Quotes {
+		if ok = p.Start == start && p.End == end; ok {
+			return
+		}
+	}
+	return
+}
+
+// Scan looks for the first unquoted and unescaped `sep` in `src`, returning
+// the text `before` and `after` the `sep` if `found`. If `sep` is not found,
+// `before` is the entire `src` input
+func (s *Scanner) Scan(src, sep string) (before, after string, found bool) {
+	if idx := s.index(src, sep); idx > -1 {
+		return src[:idx], src[idx+len(sep):], true
+	}
+	return src, "", false
+}
+
+// ScanQuote looks for the first quoted text, returning the `before`, `quoted`
+// and `after` strings if `found`. When the Scanner Escape is the backslash,
+// double and single `quoted` strings are unescaped following the Go string
+// literal rules and are left as-is if there are any invalid escape sequences
+func (s *Scanner) ScanQuote(src string) (before, quoted, after string, found bool) {
+	before, quoted, after, found, _ = s.ScanQuoteE(src)
+	return
+}
+
+// ScanQuoteE is like ScanQuote except that ScanQuoteE returns a *ScanError
+// when the first quotation is unterminated, when there is a trailing escape
+// rune or when the `quoted` text contains an invalid escape sequence. The
+// other return values are the same as ScanQuote
+func (s *Scanner) ScanQuoteE(src string) (before, quoted, after string, found bool, err error) {
+	open, end, quote, ok, st := s.quoteIndex(src)
+	if !ok {
+		err = s.stateError(src, st)
+		return src, "", "", false, err
+	}
+
+	span, err := s.quotedSpan(src, open, end, quote)
+	return src[:span.Start], span.Value, src[span.End:], true, err
+}
+
+// ScanQuoteAll returns all the quoted text found in `src`, in the order they
+// appear. Each QuotedSpan Value is unquoted the same way as ScanQuote does and
+// any unterminated quotation at the end of `src` is ignored
+func (s *Scanner) ScanQuoteAll(src string) (spans []QuotedSpan) {
+	var st scanState
+	var quote rune
+	for idx, r := range src {
+		if wasQuoted := st.quoted; s.step(&st, idx, r) {
+			continue
+		} else if wasQuoted && !st.quoted {
+			span, _ := s.quotedSpan(src, st.open, idx, quote)
+			spans = append(spans, span)
+		} else if !wasQuoted && st.quoted {
+			quote = st.quote
+		}
+	}
+	return
+}
+
+// ScanE is like Scan except that when `sep` is not found, ScanE returns a
+// *ScanError if the `src` has an unterminated quotation or ends with an
+// escape rune. The other return values are the same as Scan
+func (s *Scanner) ScanE(src, sep string) (before, after string, found bool, err error) {
+	idx, st := s.scan(src, sep)
+	if idx > -1 {
+		return src[:idx], src[idx+len(sep):], true, nil
+	}
+	return src, "", false, s.stateError(src, st)
+}
+
+// stateError returns a *ScanError if the final scanning state is within
+// a quotation or is escaping a rune past the end of the input
+func (s *Scanner) stateError(src string, st scanState) (err error) {
+	if st.escaped {
+		return newScanError(ScanTrailingEscape, src, st.escape, st.quote)
+	} else if st.quoted {
+		return newScanError(ScanUnterminatedQuote, src, st.open, st.quote)
+	}
+	return nil
+}
+
+// ScanSplit is like [strings.Split] except that only the unquoted and
+// unescaped instances of `sep` are split upon
+func (s *Scanner) ScanSplit(src, sep string) (parts []string) {
+	return s.ScanSplitN(src, sep, -1)
+}
+
+// ScanSplitN is like [strings.SplitN] except that only the unquoted and
+// unescaped instances of `sep` are split upon. The count `n` determines the
+// number of substrings to return:
+//
+//	n > 0: at most n substrings; the last substring will be the unsplit remainder
+//	n == 0: the result is nil (zero substrings)
+//	n < 0: all substrings
+func (s *Scanner) ScanSplitN(src, sep string, n int) (parts []string) {
+	if n == 0 {
+		return nil
+	} else if sep == "" {
+		return strings.SplitN(src, sep, n)
+	}
+	for n < 0 || len(parts) < n-1 {
+		idx := s.index(src, sep)
+		if idx < 0 {
+			break
+		}
+		parts = append(parts, src[:idx])
+		src = src[idx+len(sep):]
+	}
+	parts = append(parts, src)
+	return
+}
+
+// ScanSegments returns an iterator function which lazily yields each of the
+// segments ScanSplit would return, stopping early when `yield` returns false.
+// The iterator signature is compatible with the iter.Seq[string] type
+func (s *Scanner) ScanSegments(src, sep string) (segments func(yield func(segment string) bool)) {
+	return func(yield func(segment string) bool) {
+		remainder := src
+		if sep == "" {
+			for len(remainder) > 0 {
+				_, size := utf8.DecodeRuneInString(remainder)
+				if !yield(remainder[:size]) {
+					return
+				}
+				remainder = remainder[size:]
+			}
+			return
+		}
+		for {
+			idx := s.index(remainder, sep)
+			if idx < 0 {
+				break
+			} else if !yield(remainder[:idx]) {
+				return
+			}
+			remainder = remainder[idx+len(sep):]
+		}
+		yield(remainder)
+	}
+}
+
+// SplitFunc returns a [bufio.SplitFunc] which splits the input on unquoted
+// and unescaped instances of `sep`, for use with [bufio.Scanner]. The quote
+// and escape state is retained across buffer boundaries, so only the current
+// token is ever held in memory. Like [bufio.ScanLines], a trailing empty
+// token is not returned. If `sep` is empty, [bufio.ScanRunes] is returned
+//
+// The returned function is stateful and must only be used with one
+// [bufio.Scanner] instance
+func (s *Scanner) SplitFunc(sep string) (split bufio.SplitFunc) {
+	if sep == "" {
+		return bufio.ScanRunes
+	}
+
+	var st scanState
+	var offset int
+	needle := []byte(sep)
+	size := len(needle)
+
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+
+		// resume scanning where the previous call left off, data always starts
+		// at the beginning of the current token
+		for offset < len(data) {
+			if !atEOF && !utf8.FullRune(data[offset:]) {
+				// partial rune, request more data
+				break
+			}
+
+			r, width := utf8.DecodeRune(data[offset:])
+			prev := st
+
+			if s.step(&st, offset, r) {
+				if bytes.HasPrefix(data[offset:], needle) {
+					// sep match found, token complete
+					advance, token = offset+size, data[:offset]
+					st, offset = scanState{}, 0
+					return
+				} else if !atEOF && len(data)-offset < size && bytes.HasPrefix(needle, data[offset:]) {
+					// possible partial sep match, request more data
+					st = prev
+					break
+				}
+			}
+
+			offset += width
+		}
+
+		if atEOF {
+			// final token is the remainder
+			advance, token = len(data), data
+			st, offset = scanState{}, 0
+			return
+		}
+
+		// request more data
+		return 0, nil, nil
+	}
+}
+
+// ScanCarve is like Carve except that ScanCarve uses Scanner.Scan instead of
+// [strings.Cut] for finding the `end` separator
+//
+// ScanCarve is a shortcut for CarveWith and the CarveScanEnd option
+func (s *Scanner) ScanCarve(src, start, end string) (before, middle, after string, found bool) {
+	return CarveWith(src, start, end, CarveScanEnd(s))
+}
+
+// ScanBothCarve is like ScanCarve except that ScanBothCarve uses Scanner.Scan
+// to find both the `start` and `end` separators
+//
+// ScanBothCarve is a shortcut for CarveWith and the CarveScanBoth option
+func (s *Scanner) ScanBothCarve(src, start, end string) (before, middle, after string, found bool) {
+	return CarveWith(src, start, end, CarveScanBoth(s))
+}
+
+// ScanCarveAll is like CarveAll except that ScanCarveAll uses Scanner.Scan
+// instead of [strings.Cut] for finding the `end` separators
+func (s *Scanner) ScanCarveAll(src, start, end string) (carved []Carved) {
+	return carveAll(src, start, end, strings.Cut, s.Scan)
+}
+
+// ScanBothCarveAll is like ScanCarveAll except that ScanBothCarveAll uses
+// Scanner.Scan to find both the `start` and `end` separators
+func (s *Scanner) ScanBothCarveAll(src, start, end string) (carved []Carved) {
+	return carveAll(src, start, end, s.Scan, s.Scan)
+}
+
+// ScanCarveReplace is like CarveReplace except that ScanCarveReplace uses
+// Scanner.ScanCarveAll to find the regions to replace
+func (s *Scanner) ScanCarveReplace(src, start, end string, fn func(middle string) (replaced string)) (modified string) {
+	return carveReplace(src, s.ScanCarveAll(src, start, end), fn)
+}
+
+// ScanBothCarveReplace is like CarveReplace except that ScanBothCarveReplace
+// uses Scanner.ScanBothCarveAll to find the regions to replace
+func (s *Scanner) ScanBothCarveReplace(src, start, end string, fn func(middle string) (replaced string)) (modified string) {
+	return carveReplace(src, s.ScanBothCarveAll(src, start, end), fn)
+}
+
+// CarveBalanced is like ScanBothCarve except that CarveBalanced counts the
+// nesting depth of the `start` and `end` markers, returning the outermost
+// balanced region as the `middle`. Any `end` markers before the first `start`
+// are ignored. If the first `start` is never balanced, a *ScanError is
+// returned with the ScanUnbalancedMarker Kind and `found` is false
+func (s *Scanner) CarveBalanced(src, start, end string) (before, middle, after string, found bool, err error) {
+	if start == "" || end == "" {
+		return src, "", "", false, nil
+	}
+
+	open := s.index(src, start)
+	if open < 0 {
+		return src, "", "", false, nil
+	}
+
+	var st scanState
+	depth := 1
+	for idx := open + len(start); idx < len(src); {
+		r, size := utf8.DecodeRuneInString(src[idx:])
+		if s.step(&st, idx, r) {
+			if strings.HasPrefix(src[idx:], end) {
+				if depth -= 1; depth == 0 {
+					// outermost end marker found, carve complete
+					return src[:open], src[open+len(start) : idx], src[idx+len(end):], true, nil
+				}
+				idx += len(end)
+				continue
+			} else if strings.HasPrefix(src[idx:], start) {
+				// nested start marker
+				depth += 1
+				idx += len(start)
+				continue
+			}
+		}
+		idx += size
+	}
+
+	se := newScanError(ScanUnbalancedMarker, src, open, 0)
+	se.Marker = start
+	return src, "", "", false, se
+}
+
+// quotedSpan returns the QuotedSpan for the quotation starting at byte offset
+// `open` and ending at byte offset `end`, with a *ScanError if the quoted text
+// has an invalid escape sequence
+func (s *Scanner) quotedSpan(src string, open, end int, quote rune) (span QuotedSpan, err error) {
+	_, openSize := utf8.DecodeRuneInString(src[open:])
+	_, endSize := utf8.DecodeRuneInString(src[end:])
+	span.Quote = quote
+	span.Start = open
+	span.End = end + endSize
+	span.Raw = src[open+openSize : end]
+	span.Value = span.Raw
+	if s.Escape == '\\' {
+		if unquoted, offset, ee := unquote(quote, span.Raw); ee == nil {
+			span.Value = unquoted
+		} else {
+			err = newScanError(ScanInvalidEscape, src, open+openSize+offset, quote)
+		}
+	}
+	return
+}
+
+// scanState is the quotation and escape state of a Scanner pass
+type scanState struct {
+	quote   rune // starting quote rune of the current quotation
+	quoted  bool // scanning within quoted text
+	raw     bool // escapes do not apply to the current quotation
+	escaped bool // the next rune is escaped
+	open    int  // byte offset of the starting quote
+	escape  int  // byte offset of the last escape rune
+}
+
+// step updates the state with the rune `r` found at byte offset `idx`,
+// returning true if `r` is plain text: not quoted, escaped, an escape rune or
+// a quotation rune
+func (s *Scanner) step(st *scanState, idx int, r rune) (plain bool) {
+	if st.escaped {
+		// this rune is escaped, skip
+		st.escaped = false
+		return
+	} else if s.Escape != 0 && r == s.Escape && !st.raw {
+		// next rune is escaped
+		st.escaped = true
+		st.escape = idx
+		return
+	} else if st.quoted {
+		// scanning within a quoted string
+		if s.IsEnd(st.quote, r) {
+			// this rune is the ending quotation
+			st.quote, st.quoted, st.raw = 0, false, false
+		}
+		// nothing to do with quoted contents
+		return
+	} else if s.IsStart(r) {
+		// this rune is a starting quotation
+		st.quote, st.quoted, st.open = r, true, idx
+		st.raw = s.RawBackticks && r == '`'
+		return
+	}
+	return true
+}
+
+// index returns the byte offset of the first unquoted and unescaped `sep`
+// in `src`, or -1 if not present
+func (s *Scanner) index(src, sep string) (idx int) {
+	idx, _ = s.scan(src, sep)
+	return
+}
+
+// scan is the implementation of index which also returns the scanning state
+// at the point where scanning stopped
+func (s *Scanner) scan(src, sep string) (idx int, st scanState) {
+	if sep == "" {
+		return 0, st
+	}
+	for idx, r := range src {
+		if s.step(&st, idx, r) && strings.HasPrefix(src[idx:], sep) {
+			return idx, st
+		}
+	}
+	return -1, st
+}
+
+// quoteIndex returns the byte offsets of the first unescaped starting and
+// ending quotation runes in `src` along with the scanning state at the point
+// where scanning stopped
+func (s *Scanner) quoteIndex(src string) (open, end int, quote rune, found bool, st scanState) {
+	for idx, r := range src {
+		if wasQuoted := st.quoted; s.step(&st, idx, r) {
+			continue
+		} else if wasQuoted && !st.quoted {
+			return st.open, idx, quote, true, st
+		} else if !wasQuoted && st.quoted {
+			quote = st.quote
+		}
+	}
+	return
+}
+
+// unquote unescapes the `quoted` text using the Go string literal rules for
+// double and single quotes, all other quotes are returned as-is. If there is
+// an invalid escape sequence, `offset` is the byte index into `quoted` where
+// the invalid sequence starts
+func unquote(quote rune, quoted string) (unquoted string, offset int, err error) {
+	switch quote {
+	case '"', '\'':
+	default:
+		return quoted, 0, nil
+	}
+	var buf strings.Builder
+	buf.Grow(len(quoted))
+	for remainder := quoted; len(remainder) > 0; {
+		value, multibyte, tail, ee := strconv.UnquoteChar(remainder, byte(quote))
+		if ee != nil {
+			return quoted, len(quoted) - len(remainder), ee
+		} else if value < utf8.RuneSelf || !multibyte {
+			buf.WriteByte(byte(value))
+		} else {
+			buf.WriteRune(value)
+		}
+		remainder = tail
+	}
+	return buf.String(), 0, nil
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestScanner(t *testing.T) {
+
+	Convey("Scanner.Scan", t, func() {
+		checks := []struct {
+			label     string
+			scanner   *Scanner
+			src, text string
+			b, a      string
+			ok        bool
+		}{
+			{
+				"zero value scanner",
+				&Scanner{},
+				`"one,two",three`, ",",
+				`"one`, `two",three`,
+				true,
+			},
+			{
+				"default scanner",
+				NewScanner(),
+				`"one,two",three`, ",",
+				`"one,two"`, `three`,
+				true,
+			},
+			{
+				"custom escape rune",
+				&Scanner{Quotes: BasicQuotes, Escape: '^'},
+				`one\,two^,three,four`, ",",
+				`one\`, `two^,three,four`,
+				true,
+			},
+			{
+				"escapes within backticks",
+				NewScanner(),
+				"`one\\`,two`,three", ",",
+				"`one\\`,two`", "three",
+				true,
+			},
+			{
+				"raw backticks",
+				&Scanner{Quotes: BasicQuotes, Escape: '\\', RawBackticks: true},
+				"`one\\`,two`,three", ",",
+				"`one\\`", "two`,three",
+				true,
+			},
+			{
+				"fancy quotes",
+				NewFancyScanner(),
+				`“one,two”,three`, ",",
+				`“one,two”`, `three`,
+				true,
+			},
+			{
+				"fancy quotes with distinct ends",
+				NewFancyScanner(),
+				`„one,two“,„three,four”,five`, ",",
+				`„one,two“`, `„three,four”,five`,
+				true,
+			},
+			{
+				"fancy quotes not configured",
+				NewScanner(),
+				`“one,two”,three`, ",",
+				`“one`, `two”,three`,
+				true,
+			},
+			{
+				"custom quote pairs",
+				&Scanner{Quotes: []QuotePair{{Start: '(', End: ')'}}},
+				`(one,two),"three,four"`, ",",
+				`(one,two)`, `"three,four"`,
+				true,
+			},
+			{
+				"unterminated quote",
+				NewScanner(),
+				`one "two,three`, ",",
+				`one "two,three`, "",
+				false,
+			},
+		}
+
+		for _, check := range checks {
+			Convey(check.label, func() {
+				b, a, ok := check.scanner.Scan(check.src, check.text)
+				So(b, ShouldEqual, check.b)
+				So(a, ShouldEqual, check.a)
+				So(ok, ShouldEqual, check.ok)
+			})
+		}
+	})
+
+	Convey("Scanner.ScanQuote", t, func() {
+		s := NewFancyScanner()
+
+		b, q, a, ok := s.ScanQuote(`before «quoted \» text» after`)
+		So(ok, ShouldBeTrue)
+		So(b, ShouldEqual, `before `)
+		So(q, ShouldEqual, `quoted \» text`)
+		So(a, ShouldEqual, ` after`)
+
+		b, q, a, ok = s.ScanQuote(`before "quoted \"text\"" after`)
+		So(ok, ShouldBeTrue)
+		So(b, ShouldEqual, `before `)
+		So(q, ShouldEqual, `quoted "text"`)
+		So(a, ShouldEqual, ` after`)
+
+		b, q, a, ok = s.ScanQuote(`before \"not quoted\" after`)
+		So(ok, ShouldBeFalse)
+		So(b, ShouldEqual, `before \"not quoted\" after`)
+		So(q, ShouldEqual, ``)
+		So(a, ShouldEqual, ``)
+
+		s = &Scanner{Quotes: BasicQuotes, Escape: '^'}
+		b, q, a, ok = s.ScanQuote(`before "quoted ^"text^"" after`)
+		So(ok, ShouldBeTrue)
+		So(b, ShouldEqual, `before `)
+		So(q, ShouldEqual, `quoted ^"text^"`)
+		So(a, ShouldEqual, ` after`)
+	})
+
+	Convey("Scanner.ScanCarve", t, func() {
+		s := NewFancyScanner()
+
+		b, m, a, ok := s.ScanCarve(`one {{ “}}” }} two`, "{{", "}}")
+		So(ok, ShouldBeTrue)
+		So(b, ShouldEqual, `one `)
+		So(m, ShouldEqual, ` “}}” `)
+		So(a, ShouldEqual, ` two`)
+
+		b, m, a, ok = s.ScanBothCarve(`“{{” {{ “}}” }} two`, "{{", "}}")
+		So(ok, ShouldBeTrue)
+		So(b, ShouldEqual, `“{{” `)
+		So(m, ShouldEqual, ` “}}” `)
+		So(a, ShouldEqual, ` two`)
+
+		b, m, a, ok = s.ScanBothCarve(`“{{ }}”`, "{{", "}}")
+		So(ok, ShouldBeFalse)
+		So(b, ShouldEqual, `“{{ }}”`)
+		So(m, ShouldEqual, ``)
+		So(a, ShouldEqual, ``)
+	})
+
+}
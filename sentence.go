@@ -0,0 +1,86 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var gAbbreviations = newWordSet(
+	"mr mrs ms mx dr prof rev hon sr jr st",
+	"capt col gen gov lt sgt",
+	"co corp dept est inc ltd",
+	"approx ca cf etc fig no vol vs",
+	"ave blvd mt rd",
+	"jan feb mar apr jun jul aug sep sept oct nov dec",
+)
+
+// ToSentence capitalizes the first word of each sentence within the text
+// given and lower-cases all the other words, except for registered acronyms
+// which are replaced with their registered form, so that "HELLO WORLD. BYE"
+// becomes "Hello world. Bye". Sentences start at the beginning of the text and
+// after any full stop, question mark or exclamation mark which is followed by
+// white space, including those with closing quotes or brackets between the
+// mark and the white space. All punctuation and white space is kept as-is
+//
+// Full stops following common abbreviations, like "Dr." or "etc.", and
+// single letter initials, like "J." or "U.S.", do not end a sentence
+//
+// ToSentence is a wrapper around CaseConverter.ToSentence using the acronyms
+// registered with RegisterAcronyms
+func ToSentence(text string) (sentence string) {
+	return gCaseConverter.ToSentence(text)
+}
+
+// ToSentence is the acronym-aware version of the package-level ToSentence
+// function
+func (c *CaseConverter) ToSentence(text string) (sentence string) {
+	spans := wordSpans(text)
+	sentence = replaceSpans(text, spans, func(idx int, word string) (replaced string) {
+		if idx == 0 {
+			return c.titleWord(strings.ToLower(word))
+		}
+		previous := text[spans[idx-1][0]:spans[idx-1][1]]
+		if isSentenceEnd(previous, text[spans[idx-1][1]:spans[idx][0]]) {
+			return c.titleWord(strings.ToLower(word))
+		} else if acronym, ok := c.Acronym(word); ok {
+			return acronym
+		}
+		return strings.ToLower(word)
+	})
+	return
+}
+
+// isSentenceEnd returns true if the text `between` two words ends the
+// sentence containing the `previous` word
+func isSentenceEnd(previous, between string) bool {
+	mark := strings.LastIndexAny(between, ".?!")
+	if mark < 0 {
+		return false
+	}
+	rest := strings.TrimLeft(between[mark+1:], "\"')]}’”»")
+	if r, _ := utf8.DecodeRuneInString(rest); !unicode.IsSpace(r) {
+		return false
+	} else if between[mark] != '.' || strings.IndexAny(between[:mark], ".?!") > -1 {
+		return true
+	} else if utf8.RuneCountInString(previous) == 1 && unicode.IsLetter([]rune(previous)[0]) {
+		// initials
+		return false
+	}
+	_, abbreviation := gAbbreviations[strings.ToLower(previous)]
+	return !abbreviation
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSentence(t *testing.T) {
+	Convey("ToSentence", t, func() {
+		checks := []struct {
+			input    string
+			sentence string
+		}{
+			{"", ""},
+			{"hello", "Hello"},
+			{"hello world", "Hello world"},
+			{"hello. world", "Hello. World"},
+			{"what? really! yes", "What? Really! Yes"},
+			{"ask dr. smith. then go", "Ask dr. smith. Then go"},
+			{"apples, pears, etc. are fruit", "Apples, pears, etc. are fruit"},
+			{"j. r. r. tolkien wrote it", "J. r. r. tolkien wrote it"},
+			{"made in the u.s. by hand", "Made in the u.s. by hand"},
+			{"see example.com for more", "See example.com for more"},
+			{`he said "stop." then left`, `He said "stop." Then left`},
+			{"(one.) two", "(One.) Two"},
+			{"wait... what", "Wait... What"},
+			{"the id is wrong", "The ID is wrong"},
+			{"  leading space", "  Leading space"},
+			{"HELLO WORLD. BYE", "Hello world. Bye"},
+			{"HELLO WORLD! IS THE API DOWN? NO", "Hello world! Is the API down? No"},
+			{"ASK DR. SMITH. THEN GO", "Ask dr. smith. Then go"},
+			{"MADE IN THE U.S. BY HAND", "Made in the u.s. by hand"},
+			{"DON'T STOP. IT'S FINE", "Don't stop. It's fine"},
+			{"hElLo WoRlD", "Hello world"},
+			{"ÉCOLE ÉTÉ. ÇA VA", "École été. Ça va"},
+		}
+		for _, check := range checks {
+			So(ToSentence(check.input), ShouldEqual, check.sentence)
+		}
+
+		So(ToSentence(ToSpaced("firstName")), ShouldEqual, "First name")
+		for _, input := range []string{"HELLO WORLD", "the USER id", "Api Url Please"} {
+			So(ToSentence(input), ShouldEqual, ToCase(SentenceCase, input))
+			So(DetectCase(ToSentence(input)), ShouldEqual, SentenceCase)
+		}
+		So(ToSentence("id please. id now"), ShouldEqual, "ID please. ID now")
+	})
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var gTransliterations = map[rune]string{
+	// latin letters without decompositions
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'þ': "th",
+	'ł': "l", 'ı': "i", 'ħ': "h", 'ŧ': "t", 'ŋ': "ng", 'ĸ': "k",
+	// cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u", 'ђ': "dj", 'ј': "j",
+	'љ': "lj", 'њ': "nj", 'ћ': "c", 'џ': "dz",
+	// greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i",
+	'θ': "th", 'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x",
+	'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y",
+	'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// SlugOptions configures the Slugify results
+type SlugOptions struct {
+	// Separator is used between words, the default is a dash
+	Separator string
+	// MaxLength is the maximum number of bytes in the slug, zero for no limit.
+	// Slugs are truncated at word boundaries and a single word which is too
+	// long is truncated at MaxLength. When the MaxLength is too short for
+	// any words with an Existing suffix, the slug is only the number
+	MaxLength int
+	// Existing is the list of slugs already in use, a numeric suffix is added
+	// to the slug when it is one of the Existing slugs, for example "hello-2"
+	Existing []string
+}
+
+// Slugify converts the text given into a lower case URL slug. The text is
+// first transliterated to ASCII with Transliterate, any apostrophes within
+// words are removed and the remaining text is converted in the same way as
+// ToKebabs, with the SlugOptions.Separator between words. Any letters which
+// cannot be transliterated, emoji, punctuation and other symbols are dropped
+// and separate words
+//
+// An empty string is returned when there are no words in the text given
+func Slugify(text string, opts SlugOptions) (slug string) {
+	sep := opts.Separator
+	if sep == "" {
+		sep = "-"
+	}
+
+	text = strings.Map(func(r rune) rune {
+		if isApostrophe(r) {
+			return -1
+		} else if r >= utf8.RuneSelf {
+			return ' '
+		}
+		return r
+	}, Transliterate(text))
+
+	var words []string
+	for _, word := range Words(text) {
+		if word = strings.ToLower(word); word != "" {
+			words = append(words, word)
+		}
+	}
+	if len(words) == 0 {
+		return ""
+	}
+
+	slug = fitSlug(words, sep, "", opts.MaxLength)
+	if len(opts.Existing) == 0 {
+		return
+	}
+
+	existing := make(map[string]struct{}, len(opts.Existing))
+	for _, e := range opts.Existing {
+		existing[e] = struct{}{}
+	}
+	for count := 2; ; count++ {
+		if _, taken := existing[slug]; !taken {
+			return
+		}
+		slug = fitSlug(words, sep, sep+strconv.Itoa(count), opts.MaxLength)
+	}
+}
+
+// Transliterate converts the accented Latin, Cyrillic and Greek letters within
+// the text given to ASCII letters, for example "Ελληνικά" becomes "Ellinika"
+// and "Ёлка" becomes "Yolka". Other letters with diacritics are replaced with
+// their base letter and all other runes are kept as-is
+func Transliterate(text string) (transliterated string) {
+	var buf strings.Builder
+	buf.Grow(len(text))
+	for _, r := range norm.NFC.String(text) {
+		if r < utf8.RuneSelf {
+			buf.WriteRune(r)
+			continue
+		}
+		if ascii, ok := transliterateRune(r); ok {
+			buf.WriteString(ascii)
+			continue
+		}
+		for _, d := range norm.NFKD.String(string(r)) {
+			if unicode.Is(unicode.Mn, d) {
+				continue
+			} else if ascii, ok := transliterateRune(d); ok {
+				buf.WriteString(ascii)
+			} else {
+				buf.WriteRune(d)
+			}
+		}
+	}
+	transliterated = buf.String()
+	return
+}
+
+// transliterateRune looks up the ASCII form of the rune given, upper case
+// runes are capitalized
+func transliterateRune(r rune) (ascii string, ok bool) {
+	lower := unicode.ToLower(r)
+	if ascii, ok = gTransliterations[lower]; ok && lower != r {
+		ascii = capitalize(ascii)
+	}
+	return
+}
+
+// fitSlug joins as many of the `words` as will fit within the `maxLength`,
+// including the `suffix` given. A single word which does not fit is truncated
+func fitSlug(words []string, sep, suffix string, maxLength int) (slug string) {
+	if maxLength <= 0 {
+		return strings.Join(words, sep) + suffix
+	}
+
+	limit := maxLength - len(suffix)
+	if limit < 1 {
+		// only the suffix fits
+		return strings.TrimPrefix(suffix, sep)
+	}
+
+	var buf strings.Builder
+	for idx, word := range words {
+		size := len(word)
+		if idx > 0 {
+			size += len(sep)
+		}
+		if buf.Len()+size > limit {
+			if idx == 0 {
+				buf.WriteString(word[:limit])
+			}
+			break
+		}
+		if idx > 0 {
+			buf.WriteString(sep)
+		}
+		buf.WriteString(word)
+	}
+
+	slug = buf.String() + suffix
+	return
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSlug(t *testing.T) {
+	Convey("Transliterate", t, func() {
+		So(Transliterate(""), ShouldEqual, "")
+		So(Transliterate("plain text"), ShouldEqual, "plain text")
+		So(Transliterate("Crème Brûlée"), ShouldEqual, "Creme Brulee")
+		So(Transliterate("Straße Œuvre Ørsted Łódź"), ShouldEqual, "Strasse Oeuvre Orsted Lodz")
+		So(Transliterate("Ёлка Щука"), ShouldEqual, "Yolka Shchuka")
+		So(Transliterate("Ελληνικά"), ShouldEqual, "Ellinika")
+		So(Transliterate("日本 ❤"), ShouldEqual, "日本 ❤")
+	})
+
+	Convey("Slugify", t, func() {
+		checks := []struct {
+			input string
+			opts  SlugOptions
+			slug  string
+		}{
+			{"", SlugOptions{}, ""},
+			{"!!! ❤ ???", SlugOptions{}, ""},
+			{"Hello, World!", SlugOptions{}, "hello-world"},
+			{"  Hello --  World__ ", SlugOptions{}, "hello-world"},
+			{"Crème Brûlée Recipe", SlugOptions{}, "creme-brulee-recipe"},
+			{"Привет, мир", SlugOptions{}, "privet-mir"},
+			{"Καλημέρα κόσμε", SlugOptions{}, "kalimera-kosme"},
+			{"I ❤ Go 🚀 2024", SlugOptions{}, "i-go-2024"},
+			{"Don't Stop Believin’", SlugOptions{}, "dont-stop-believin"},
+			{"The HTTPServer guide", SlugOptions{}, "the-http-server-guide"},
+			{"日本 and Go", SlugOptions{}, "and-go"},
+			{"Hello World", SlugOptions{Separator: "_"}, "hello_world"},
+			{"one two three four", SlugOptions{MaxLength: 13}, "one-two-three"},
+			{"one two three four", SlugOptions{MaxLength: 12}, "one-two"},
+			{"supercalifragilistic word", SlugOptions{MaxLength: 5}, "super"},
+			{"Hello World", SlugOptions{Existing: []string{"hello"}}, "hello-world"},
+			{"Hello World", SlugOptions{Existing: []string{"hello-world"}}, "hello-world-2"},
+			{"Hello World", SlugOptions{Existing: []string{"hello-world", "hello-world-2"}}, "hello-world-3"},
+			{"Hello World", SlugOptions{MaxLength: 11, Existing: []string{"hello-world"}}, "hello-2"},
+			{"Hello World", SlugOptions{MaxLength: 7, Existing: []string{"hello-world", "hello", "hello-2"}}, "hello-3"},
+			{"Hello World", SlugOptions{MaxLength: 2, Existing: []string{"he"}}, "2"},
+		}
+		for _, check := range checks {
+			So(Slugify(check.input, check.opts), ShouldEqual, check.slug)
+		}
+	})
+}
@@ -33,17 +33,25 @@ func SplitSortReversed(input, separator string) (split []string) {
 }
 
 // SortedByLastName returns a natual-sorted list of the full
-// names given
+// names given, sorted by their Last names and then by their First names, see
+// ParseName
 func SortedByLastName(fullNames []string) (sorted []string) {
-	lookup := make(map[int]string)
+	names := make([]Name, len(fullNames))
+	order := make([]int, len(fullNames))
 	for idx, key := range fullNames {
-		lookup[idx] = LastName(key)
-		sorted = append(sorted, key)
+		names[idx] = ParseName(key)
+		order[idx] = idx
 	}
-	sort.Slice(sorted, func(i, j int) (less bool) {
-		less = natural.Less(lookup[i], lookup[j])
-		return less
+	sort.SliceStable(order, func(i, j int) (less bool) {
+		a, b := names[order[i]], names[order[j]]
+		if a.Last != b.Last {
+			return natural.Less(a.Last, b.Last)
+		}
+		return natural.Less(a.First, b.First)
 	})
+	for _, idx := range order {
+		sorted = append(sorted, fullNames[idx])
+	}
 	return
 }
 
@@ -37,6 +37,19 @@ func TestSort(t *testing.T) {
 			"Another LastName",
 			"First Name",
 		})
+		So(SortedByLastName([]string{
+			"Zed Adams",
+			"Jan van der Berg",
+			"Amy Adams",
+			"Smith, John",
+			"Dr. Bob Smith Jr.",
+		}), ShouldEqual, []string{
+			"Amy Adams",
+			"Zed Adams",
+			"Dr. Bob Smith Jr.",
+			"Smith, John",
+			"Jan van der Berg",
+		})
 	})
 
 	Convey("SortByLength", t, func() {
@@ -19,18 +19,46 @@ import (
 	"strconv"
 	"strings"
 	"unicode"
-
-	"github.com/iancoleman/strcase"
 )
 
 // ToKebabs converts all the given strings to kebab-case
 func ToKebabs(inputs ...string) (out []string) {
 	for _, i := range inputs {
-		out = append(out, strcase.ToKebab(i))
+		out = append(out, toKebab(i))
 	}
 	return
 }
 
+// ToSnakes converts all the given strings to snake_case
+func ToSnakes(inputs ...string) (out []string) {
+	return ToCases(SnakeCase, inputs...)
+}
+
+// ToCamels converts all the given strings to camelCase
+func ToCamels(inputs ...string) (out []string) {
+	return ToCases(CamelCase, inputs...)
+}
+
+// ToPascals converts all the given strings to PascalCase
+func ToPascals(inputs ...string) (out []string) {
+	return ToCases(PascalCase, inputs...)
+}
+
+// ToScreamingSnakes converts all the given strings to SCREAMING_SNAKE_CASE
+func ToScreamingSnakes(inputs ...string) (out []string) {
+	return ToCases(ScreamingSnakeCase, inputs...)
+}
+
+// ToDots converts all the given strings to dot.case
+func ToDots(inputs ...string) (out []string) {
+	return ToCases(DotCase, inputs...)
+}
+
+// ToPaths converts all the given strings to path/case
+func ToPaths(inputs ...string) (out []string) {
+	return ToCases(PathCase, inputs...)
+}
+
 // ToLowers converts all the given strings to lower case
 func ToLowers(in ...string) (out []string) {
 	for _, i := range in {
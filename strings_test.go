@@ -23,6 +23,12 @@ import (
 func TestStrings(t *testing.T) {
 	Convey("ToKebabs, ToLowers", t, func() {
 		So(ToKebabs("OneTwo", "ManyMore"), ShouldEqual, []string{"one-two", "many-more"})
+		So(ToSnakes("OneTwo", "userID"), ShouldEqual, []string{"one_two", "user_id"})
+		So(ToCamels("one_two", "user_id"), ShouldEqual, []string{"oneTwo", "userID"})
+		So(ToPascals("one_two", "user_id"), ShouldEqual, []string{"OneTwo", "UserID"})
+		So(ToScreamingSnakes("oneTwo", "v2Api"), ShouldEqual, []string{"ONE_TWO", "V2_API"})
+		So(ToDots("OneTwo"), ShouldEqual, []string{"one.two"})
+		So(ToPaths("OneTwo"), ShouldEqual, []string{"one/two"})
 		So(ToLowers("One", "Two"), ShouldEqual, []string{"one", "two"})
 	})
 
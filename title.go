@@ -0,0 +1,137 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TitleStyle specifies the style guide rules used by ToTitleStyle
+type TitleStyle uint8
+
+const (
+	// TitleAP follows the Associated Press rules: articles, coordinating
+	// conjunctions and prepositions of three letters or fewer are lower-cased
+	TitleAP TitleStyle = iota
+	// TitleChicago follows the Chicago Manual of Style rules: articles, the
+	// conjunctions "and", "but", "for", "nor" and "or", the words "as" and
+	// "to" and all prepositions, regardless of length, are lower-cased
+	TitleChicago
+	// TitleAPA follows the American Psychological Association rules: articles,
+	// conjunctions and prepositions of three letters or fewer are lower-cased
+	TitleAPA
+)
+
+var gTitleMinorWords = map[TitleStyle]map[string]struct{}{
+	TitleAP: newWordSet(
+		"a an the",
+		"and but for nor or so yet",
+		"as at by in of off on out per to up via",
+	),
+	TitleChicago: newWordSet(
+		"a an the",
+		"and but for nor or",
+		"as to",
+		"about above across after against along amid among around at before",
+		"behind below beneath beside besides between beyond by despite down",
+		"during except from in inside into like near of off on onto opposite",
+		"out outside over past per since than through throughout till toward",
+		"towards under underneath unlike until up upon via with within without",
+	),
+	TitleAPA: newWordSet(
+		"a an the",
+		"and as but for if nor or so yet",
+		"at by in of off on per to up via",
+	),
+}
+
+// ToTitleStyle title-cases the given text according to the TitleStyle rules.
+// The first and last words are always capitalized, as are the first and last
+// words around a colon, question mark, exclamation mark or em dash, which
+// separate a title from its subtitle. All other words which are minor words
+// for the TitleStyle are lower-cased and the remaining words are capitalized
+// in the same way as ToTitleWords
+//
+// Words which already contain upper case letters after their first letter,
+// such as all-caps words like "NASA" or brand names like "iPhone", are kept
+// as-is. Each part of a hyphenated compound is a separate word, so that
+// "state-of-the-art" becomes "State-of-the-Art", and apostrophes within
+// words are not word boundaries, so that "don't" becomes "Don't"
+//
+// ToTitleStyle is a wrapper around CaseConverter.ToTitleStyle using the
+// acronyms registered with RegisterAcronyms
+func ToTitleStyle(text string, style TitleStyle) (titled string) {
+	return gCaseConverter.ToTitleStyle(text, style)
+}
+
+// ToTitleStyle is the acronym-aware version of the package-level ToTitleStyle
+// function
+func (c *CaseConverter) ToTitleStyle(text string, style TitleStyle) (titled string) {
+	spans := wordSpans(text)
+	minor := gTitleMinorWords[style]
+	last := len(spans) - 1
+
+	// isBoundary returns true if the word at `idx` is the first or last word
+	// of the title or subtitle
+	isBoundary := func(idx int) bool {
+		return idx == 0 || idx == last ||
+			isTitleBreak(text[spans[idx-1][1]:spans[idx][0]]) ||
+			isTitleBreak(text[spans[idx][1]:spans[idx+1][0]])
+	}
+
+	titled = replaceSpans(text, spans, func(idx int, word string) (replaced string) {
+		if hasInnerUpper(word) {
+			return word
+		} else if lower := strings.ToLower(word); !isBoundary(idx) {
+			if _, ok := minor[lower]; ok {
+				return lower
+			}
+		}
+		return c.titleWord(word)
+	})
+	return
+}
+
+// newWordSet returns a lookup map of all the space separated words within
+// the lists given
+func newWordSet(lists ...string) (set map[string]struct{}) {
+	set = make(map[string]struct{})
+	for _, list := range lists {
+		for _, word := range strings.Fields(list) {
+			set[word] = struct{}{}
+		}
+	}
+	return
+}
+
+// isTitleBreak returns true if the text between two words separates a title
+// from a subtitle
+func isTitleBreak(between string) bool {
+	return strings.ContainsAny(between, ":?!—")
+}
+
+// hasInnerUpper returns true if the `word` has any upper case letters after
+// the first rune
+func hasInnerUpper(word string) bool {
+	_, size := utf8.DecodeRuneInString(word)
+	for _, r := range word[size:] {
+		if unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTitleStyle(t *testing.T) {
+	Convey("ToTitleStyle", t, func() {
+		checks := []struct {
+			input  string
+			style  TitleStyle
+			titled string
+		}{
+			{"", TitleAP, ""},
+			{"the", TitleAP, "The"},
+			{"a tale of two cities", TitleAP, "A Tale of Two Cities"},
+			{"gone with the wind", TitleAP, "Gone With the Wind"},
+			{"gone with the wind", TitleChicago, "Gone with the Wind"},
+			{"gone with the wind", TitleAPA, "Gone With the Wind"},
+			{"the fox jumped over the dog", TitleAP, "The Fox Jumped Over the Dog"},
+			{"the fox jumped over the dog", TitleChicago, "The Fox Jumped over the Dog"},
+			{"slow and steady so far", TitleAP, "Slow and Steady so Far"},
+			{"slow and steady so far", TitleChicago, "Slow and Steady So Far"},
+			{"what if it works", TitleAP, "What If It Works"},
+			{"what if it works", TitleAPA, "What if It Works"},
+			{"what it is made of", TitleAP, "What It Is Made Of"},
+			{"THE LORD OF THE RINGS", TitleAP, "THE LORD OF THE RINGS"},
+			{"a NASA report on the iPhone", TitleAP, "A NASA Report on the iPhone"},
+			{"don't stop believin'", TitleAP, "Don't Stop Believin'"},
+			{"it’s a dog’s life", TitleAP, "It’s a Dog’s Life"},
+			{"the state-of-the-art guide", TitleChicago, "The State-of-the-Art Guide"},
+			{"a self-driving car", TitleAPA, "A Self-Driving Car"},
+			{"the api of the url", TitleAP, "The API of the URL"},
+			{"star wars: a new hope", TitleAP, "Star Wars: A New Hope"},
+			{"who is it for? a guide", TitleAP, "Who Is It For? A Guide"},
+			{"(the) end", TitleAP, "(The) End"},
+			{"été à la plage", TitleAP, "Été À La Plage"},
+		}
+
+		for _, check := range checks {
+			So(ToTitleStyle(check.input, check.style), ShouldEqual, check.titled)
+		}
+
+		c := NewCaseConverter("ID")
+		So(c.ToTitleStyle("the user id of the day", TitleChicago), ShouldEqual, "The User ID of the Day")
+	})
+}
@@ -0,0 +1,266 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DigitRule specifies how a Segmenter handles digits within words
+type DigitRule uint8
+
+const (
+	// DigitsJoin keeps digits attached to the preceding letters and any
+	// lowercase letters following the digits, for example: "v2Api" is
+	// segmented into "v2" and "Api"
+	DigitsJoin DigitRule = iota
+	// DigitsSplit separates all runs of digits into their own words, for
+	// example: "v2Api" is segmented into "v", "2" and "Api"
+	DigitsSplit
+)
+
+// Segmenter splits text into words, used by all the case converters
+//
+// Text is first split on all runes which are not letters, numbers or marks
+// (spaces, punctuation, symbols, etc) and then each of those tokens is split
+// into words on lower-to-upper case changes (oneTwo), the last upper case
+// letter of an upper case run followed by lower case letters (HTTPServer),
+// transitions between letters with case and letters without case (scripts
+// like Han or Arabic) and transitions between digits and letters according
+// to the Digits rule. Upper case runs followed by a lone "s" are kept as
+// plural initialisms (IDs)
+type Segmenter struct {
+	// Acronyms is a list of words which are kept together. Upper case runs
+	// which are entirely made up of Acronyms are split into those Acronyms,
+	// for example with "API" and "URL", "APIURL" is segmented into "API" and
+	// "URL" instead of being one word. Acronyms with lower case letters, like
+	// "OAuth", are matched case-sensitively at the start of words and are
+	// preserved instead of being split on the case changes
+	Acronyms []string
+	// Digits specifies the handling of digits within words
+	Digits DigitRule
+}
+
+// NewSegmenter returns a new Segmenter instance without any Acronyms and
+// using the DigitsJoin rule
+func NewSegmenter() (s *Segmenter) {
+	s = &Segmenter{
+		Digits: DigitsJoin,
+	}
+	return
+}
+
+// Words splits the text given into words, see Segmenter for the details
+//
+// Words is a wrapper around CaseConverter.Words using the acronyms registered
+// with RegisterAcronyms
+func Words(text string) (words []string) {
+	return gCaseConverter.Words(text)
+}
+
+// Words splits the text given into words, see Segmenter for the details
+func (s *Segmenter) Words(text string) (words []string) {
+	return s.words(text, newAcronymSet(s.Acronyms...))
+}
+
+// words is the implementation of Words using the acronymSet given
+func (s *Segmenter) words(text string, acronyms *acronymSet) (words []string) {
+	for _, token := range strings.FieldsFunc(text, isWordDelimiter) {
+		words = s.appendWords(words, []rune(token), acronyms)
+	}
+	return
+}
+
+// appendWords appends all the words found in the token given
+func (s *Segmenter) appendWords(words []string, token []rune, acronyms *acronymSet) []string {
+	classes := make([]runeClass, len(token))
+	for idx, r := range token {
+		classes[idx] = classifyRune(r)
+	}
+	for idx := 0; idx < len(token); {
+		end := acronyms.mixedEnd(token, classes, idx)
+		if end == 0 {
+			end = s.wordEnd(token, classes, idx)
+			if classes[idx] == upperClass {
+				// upper case runs may be made up of acronyms
+				if tiles := acronyms.tile(string(token[idx:end])); len(tiles) > 1 {
+					words = append(words, tiles...)
+					idx = end
+					continue
+				}
+			}
+		}
+		words = append(words, string(token[idx:end]))
+		idx = end
+	}
+	return words
+}
+
+// wordEnd returns the index following the word starting at `idx`
+func (s *Segmenter) wordEnd(token []rune, classes []runeClass, idx int) (end int) {
+	total := len(classes)
+	class := classes[idx]
+	end = runEnd(classes, idx+1, class)
+
+	switch class {
+	case upperClass:
+		last := end - 1
+		for last > idx && classes[last] == markClass {
+			last -= 1
+		}
+		if last == idx {
+			// capitalized word
+			end = runEnd(classes, end, lowerClass)
+		} else if end < total && classes[end] == lowerClass {
+			if token[end] == 's' && (end+1 == total || classes[end+1] != lowerClass) {
+				// plural initialism
+				end += 1
+			} else {
+				// last upper case letter starts the next word
+				end = last
+			}
+		}
+	case digitClass:
+		if s.Digits == DigitsJoin {
+			end = runEnd(classes, end, lowerClass)
+		}
+		return
+	}
+
+	if s.Digits == DigitsJoin && class != otherClass && end < total && classes[end] == digitClass {
+		end = runEnd(classes, end, digitClass)
+		end = runEnd(classes, end, lowerClass)
+	}
+	return
+}
+
+// acronymSet is the compiled form of a list of acronyms
+type acronymSet struct {
+	upper map[string]struct{} // acronyms without lower case letters
+	mixed [][]rune            // acronyms with lower case letters, longest first
+}
+
+// newAcronymSet compiles the acronyms given
+func newAcronymSet(acronyms ...string) (set *acronymSet) {
+	set = &acronymSet{upper: make(map[string]struct{})}
+	for _, acronym := range acronyms {
+		if acronym == "" {
+			continue
+		} else if strings.ToUpper(acronym) == acronym {
+			set.upper[acronym] = struct{}{}
+		} else {
+			set.mixed = append(set.mixed, []rune(acronym))
+		}
+	}
+	sort.SliceStable(set.mixed, func(i, j int) (less bool) {
+		return len(set.mixed[i]) > len(set.mixed[j])
+	})
+	return
+}
+
+// mixedEnd returns the index following the longest mixed case acronym found
+// at `idx`, or zero if there are none
+func (set *acronymSet) mixedEnd(token []rune, classes []runeClass, idx int) (end int) {
+	for _, acronym := range set.mixed {
+		size := len(acronym)
+		if idx+size > len(token) || string(token[idx:idx+size]) != string(acronym) {
+			continue
+		} else if end = idx + size; end < len(token) && classes[end] == lowerClass {
+			// acronym is the start of some other word
+			end = 0
+			continue
+		}
+		return
+	}
+	return
+}
+
+// tile splits the upper case `word` into the upper case acronyms it is made
+// up of, returning nil if the `word` is not entirely made up of acronyms. A
+// trailing lower case "s" is kept with the last acronym
+func (set *acronymSet) tile(word string) (tiles []string) {
+	if len(set.upper) == 0 {
+		return
+	}
+	var plural bool
+	if plural = strings.HasSuffix(word, "s"); plural {
+		word = word[:len(word)-1]
+	}
+	if tiles = set.tiling(word); plural && len(tiles) > 0 {
+		tiles[len(tiles)-1] += "s"
+	}
+	return
+}
+
+// tiling recursively finds the longest-first tiling of `word` with the upper
+// case acronyms
+func (set *acronymSet) tiling(word string) (tiles []string) {
+	for size := len(word); size > 0; size-- {
+		head := word[:size]
+		if _, ok := set.upper[head]; !ok {
+			continue
+		} else if size == len(word) {
+			return []string{head}
+		} else if rest := set.tiling(word[size:]); rest != nil {
+			return append([]string{head}, rest...)
+		}
+	}
+	return nil
+}
+
+// runeClass is the Segmenter classification of a rune
+type runeClass uint8
+
+const (
+	otherClass runeClass = iota // letters without case
+	upperClass
+	lowerClass
+	digitClass
+	markClass
+)
+
+// classifyRune returns the runeClass of the rune given
+func classifyRune(r rune) (class runeClass) {
+	switch {
+	case unicode.IsMark(r):
+		return markClass
+	case unicode.IsNumber(r):
+		return digitClass
+	case unicode.IsUpper(r), unicode.IsTitle(r):
+		return upperClass
+	case unicode.IsLower(r):
+		return lowerClass
+	}
+	return otherClass
+}
+
+// runEnd returns the index following the run of `class` runes, including
+// any marks, starting at `idx`
+func runEnd(classes []runeClass, idx int, class runeClass) (end int) {
+	for end = idx; end < len(classes); end++ {
+		if c := classes[end]; c != class && c != markClass {
+			break
+		}
+	}
+	return
+}
+
+// isWordDelimiter returns true if the rune given is not a letter, number or
+// mark
+func isWordDelimiter(r rune) (delimiter bool) {
+	return !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsMark(r)
+}
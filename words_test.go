@@ -0,0 +1,77 @@
+// Copyright (c) 2024  The Go-CoreLibs Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strings
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWords(t *testing.T) {
+	Convey("Words", t, func() {
+		checks := []struct {
+			input  string
+			output []string
+		}{
+			{"", nil},
+			{" - _ ", nil},
+			{"one", []string{"one"}},
+			{"one two", []string{"one", "two"}},
+			{"one-two_three.four/five", []string{"one", "two", "three", "four", "five"}},
+			{"oneTwo", []string{"one", "Two"}},
+			{"OneTwo", []string{"One", "Two"}},
+			{"HTTPServerID", []string{"HTTP", "Server", "ID"}},
+			{"UserIDs", []string{"User", "IDs"}},
+			{"APIURL", []string{"API", "URL"}},
+			{"APIURLs", []string{"API", "URLs"}},
+			{"IDEConfig", []string{"IDE", "Config"}},
+			{"v2Api", []string{"v2", "Api"}},
+			{"Version2Beta", []string{"Version2", "Beta"}},
+			{"UTF8String", []string{"UTF8", "String"}},
+			{"2fa", []string{"2fa"}},
+			{"10X", []string{"10", "X"}},
+			{"日本Tokyo", []string{"日本", "Tokyo"}},
+			{"日本tokyo", []string{"日本", "tokyo"}},
+			{"ünïCödé", []string{"ünï", "Cödé"}},
+			{"éléÉlé", []string{"élé", "Élé"}},
+			{"ΑθήναΚρήτη", []string{"Αθήνα", "Κρήτη"}},
+		}
+		for _, check := range checks {
+			So(Words(check.input), ShouldEqual, check.output)
+		}
+	})
+
+	Convey("Segmenter", t, func() {
+		s := &Segmenter{Digits: DigitsSplit}
+		So(s.Words("v2Api"), ShouldEqual, []string{"v", "2", "Api"})
+		So(s.Words("Version2beta"), ShouldEqual, []string{"Version", "2", "beta"})
+		So(s.Words("2fa"), ShouldEqual, []string{"2", "fa"})
+
+		s = NewSegmenter()
+		So(s.Words("APIURL"), ShouldEqual, []string{"APIURL"})
+
+		s = &Segmenter{Acronyms: []string{"API", "URL", "OAuth", "ID", "IDE"}}
+		So(s.Words("APIURL"), ShouldEqual, []string{"API", "URL"})
+		So(s.Words("APIURLs"), ShouldEqual, []string{"API", "URLs"})
+		So(s.Words("apiURLs"), ShouldEqual, []string{"api", "URLs"})
+		So(s.Words("APIXURL"), ShouldEqual, []string{"APIXURL"})
+		So(s.Words("OAuthToken"), ShouldEqual, []string{"OAuth", "Token"})
+		So(s.Words("OAuthor"), ShouldEqual, []string{"O", "Author"})
+		So(s.Words("IDEntity"), ShouldEqual, []string{"ID", "Entity"})
+		So(s.Words("IDEConfig"), ShouldEqual, []string{"IDE", "Config"})
+		So(s.Words("Identity"), ShouldEqual, []string{"Identity"})
+	})
+}